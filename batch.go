@@ -0,0 +1,220 @@
+package smartlogic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// DefaultBatchSize is the number of concepts sent in a single request by CreateConcepts.
+const DefaultBatchSize = 100
+
+// ConceptResult is the outcome of creating a single concept by CreateConcepts.
+type ConceptResult struct {
+	Concept Concept
+	// Err is nil if the concept was created.
+	Err error
+}
+
+// CreateConcepts creates the concepts in the task, packing them into JSON-LD @graph batches of BatchSize
+// (DefaultBatchSize by default). When Smartlogic rejects a batch, it is split into halves which are sent again
+// to isolate the rejected concepts, so a single invalid concept does not fail the others.
+// It returns the result of each concept in the order of the input.
+func (c *Client) CreateConcepts(ctx context.Context, task string, concepts []Concept, opts ...BatchOption) []ConceptResult {
+	options := newBatchOptions(opts)
+	batchSize := options.batchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	results := make([]ConceptResult, len(concepts))
+	var valid []int
+	for i, concept := range concepts {
+		results[i].Concept = concept
+		if err := validateNewConcept(concept); err != nil {
+			results[i].Err = err
+			continue
+		}
+		valid = append(valid, i)
+	}
+
+	for start := 0; start < len(valid); start += batchSize {
+		end := start + batchSize
+		if end > len(valid) {
+			end = len(valid)
+		}
+		c.createConceptBatch(ctx, task, results, valid[start:end], options.write)
+	}
+
+	return results
+}
+
+// createConceptBatch creates the concepts at the given indexes of the results in a single request,
+// splitting the batch when it is rejected by Smartlogic.
+func (c *Client) createConceptBatch(ctx context.Context, task string, results []ConceptResult, batch []int, opts []WriteOption) {
+	graph := make([]orderedObject, 0, len(batch))
+	for _, i := range batch {
+		graph = append(graph, results[i].Concept.toInput().object(c.namespace))
+	}
+	body, err := json.Marshal(struct {
+		Graph []orderedObject `json:"@graph"`
+	}{graph})
+	if err != nil {
+		err = fmt.Errorf("failed json encoding concepts: %w", err)
+	} else {
+		err = c.postConcepts(ctx, task, body, opts)
+	}
+	if err == nil {
+		return
+	}
+
+	// Only the rejections of the content by Smartlogic are caused by a concept in the batch. Other failures,
+	// e.g. outages or throttling, would fail the smaller batches too and splitting would only add load.
+	var apiErr *APIError
+	if len(batch) > 1 && errors.As(err, &apiErr) && isContentRejection(apiErr.StatusCode) && ctx.Err() == nil {
+		half := len(batch) / 2
+		c.createConceptBatch(ctx, task, results, batch[:half], opts)
+		c.createConceptBatch(ctx, task, results, batch[half:], opts)
+		return
+	}
+
+	for _, i := range batch {
+		results[i].Err = err
+	}
+}
+
+// isContentRejection reports whether the http status means Smartlogic rejected the content of the request.
+func isContentRejection(status int) bool {
+	switch status {
+	case http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity:
+		return true
+	}
+	return false
+}
+
+// DefaultConcurrency is the number of requests made in parallel by ApplyMetadataUpdates.
+const DefaultConcurrency = 4
+
+// MetadataUpdate is a single value of the metadata field to be added to a concept.
+type MetadataUpdate struct {
+	ConceptID string
+	FieldName string
+	Value     MetadataValue
+}
+
+// MetadataUpdateResult is the outcome of a failed MetadataUpdate.
+type MetadataUpdateResult struct {
+	// Index is the position of the update in the input.
+	Index  int
+	Update MetadataUpdate
+	Err    error
+}
+
+// MetadataBatchReport aggregates the results of ApplyMetadataUpdates.
+type MetadataBatchReport struct {
+	Succeeded int
+	// Failed are the updates which were not applied, ordered by their index.
+	Failed []MetadataUpdateResult
+	// Checkpoint is the number of leading updates which were all applied. An interrupted or partially failed batch
+	// can be resumed from it, as adding the same metadata value again does not change the concept.
+	Checkpoint int
+	// Err is the error of the context if the batch was cancelled before all the updates were applied.
+	Err error
+}
+
+// ApplyMetadataUpdates adds the metadata values to the concepts in the task, making up to Concurrency
+// (DefaultConcurrency by default) requests in parallel. It stops when the context is done.
+func (c *Client) ApplyMetadataUpdates(ctx context.Context, task string, updates []MetadataUpdate, opts ...BatchOption) MetadataBatchReport {
+	ch := make(chan MetadataUpdate)
+	go func() {
+		defer close(ch)
+		for _, update := range updates {
+			select {
+			case ch <- update:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return c.ApplyMetadataUpdateStream(ctx, task, ch, opts...)
+}
+
+// ApplyMetadataUpdateStream is like ApplyMetadataUpdates, but reads the updates from the channel until it is closed.
+// The updates are indexed in the order they are received.
+func (c *Client) ApplyMetadataUpdateStream(ctx context.Context, task string, updates <-chan MetadataUpdate, opts ...BatchOption) MetadataBatchReport {
+	options := newBatchOptions(opts)
+	concurrency := options.concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	type job struct {
+		index  int
+		update MetadataUpdate
+	}
+	jobs := make(chan job)
+	results := make(chan MetadataUpdateResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				err := c.AddConceptMetadataValue(ctx, j.update.ConceptID, j.update.FieldName, j.update.Value, task, options.write...)
+				results <- MetadataUpdateResult{Index: j.index, Update: j.update, Err: err}
+			}
+		}()
+	}
+
+	go func() {
+		defer func() {
+			close(jobs)
+			wg.Wait()
+			close(results)
+		}()
+		for index := 0; ; index++ {
+			var update MetadataUpdate
+			select {
+			case u, ok := <-updates:
+				if !ok {
+					return
+				}
+				update = u
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- job{index, update}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	report := MetadataBatchReport{}
+	succeeded := map[int]bool{}
+	for result := range results {
+		if result.Err != nil {
+			report.Failed = append(report.Failed, result)
+			continue
+		}
+		report.Succeeded++
+		succeeded[result.Index] = true
+		for succeeded[report.Checkpoint] {
+			delete(succeeded, report.Checkpoint)
+			report.Checkpoint++
+		}
+	}
+
+	sort.Slice(report.Failed, func(i, j int) bool {
+		return report.Failed[i].Index < report.Failed[j].Index
+	})
+	report.Err = ctx.Err()
+	return report
+}
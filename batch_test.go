@@ -0,0 +1,259 @@
+package smartlogic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClientCreateConcepts(t *testing.T) {
+	var batchSizes []int
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Graph []json.RawMessage `json:"@graph"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Errorf("invalid body send on create concepts: %v", err)
+		}
+		batchSizes = append(batchSizes, len(body.Graph))
+		for _, node := range body.Graph {
+			if strings.Contains(string(node), "Rejected") {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors":[{"message":"invalid concept"}]}`))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer testServer.Close()
+
+	ctx := context.TODO()
+	client, err := New(ctx,
+		WithHTTPClient(testServer.Client()),
+		WithBaseURL(testServer.URL),
+		WithClientID("testClientID"),
+		WithModel("testModel"),
+		WithTokenSource(StaticTokenSource("test_token")),
+	)
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	concepts := []Concept{
+		{PrefLabel: "First", Type: TypeOrganisation, SchemaObject: ConceptSchemaOrganisation},
+		{PrefLabel: "Second", Type: TypeOrganisation, SchemaObject: ConceptSchemaOrganisation},
+		{PrefLabel: "Rejected", Type: TypeOrganisation, SchemaObject: ConceptSchemaOrganisation},
+		{PrefLabel: "Fourth", Type: TypeOrganisation, SchemaObject: ConceptSchemaOrganisation},
+		{PrefLabel: "Invalid", SchemaObject: ConceptSchemaOrganisation},
+		{PrefLabel: "Sixth", Type: TypeOrganisation, SchemaObject: ConceptSchemaOrganisation},
+	}
+	results := client.CreateConcepts(ctx, "testTask", concepts, BatchSize(4))
+
+	if len(results) != len(concepts) {
+		t.Fatalf("unexpected number of results, got %d, want %d", len(results), len(concepts))
+	}
+	for i, result := range results {
+		if result.Concept.PrefLabel != concepts[i].PrefLabel {
+			t.Errorf("unexpected concept of result %d, got %v, want %v", i, result.Concept.PrefLabel, concepts[i].PrefLabel)
+		}
+		shouldFail := result.Concept.PrefLabel == "Rejected" || result.Concept.PrefLabel == "Invalid"
+		if result.Err != nil && !shouldFail {
+			t.Errorf("unexpected error creating concept %v: %v", result.Concept.PrefLabel, result.Err)
+		}
+		if result.Err == nil && shouldFail {
+			t.Errorf("expected error creating concept %v", result.Concept.PrefLabel)
+		}
+	}
+
+	// The first batch of four is rejected and split until the rejected concept is isolated,
+	// the invalid concept is never sent.
+	expectedBatchSizes := []int{4, 2, 2, 1, 1, 1}
+	if len(batchSizes) != len(expectedBatchSizes) {
+		t.Fatalf("unexpected batches sent, got %v, want %v", batchSizes, expectedBatchSizes)
+	}
+	for i := range batchSizes {
+		if batchSizes[i] != expectedBatchSizes[i] {
+			t.Errorf("unexpected batches sent, got %v, want %v", batchSizes, expectedBatchSizes)
+			break
+		}
+	}
+}
+
+func TestClientCreateConceptsNotSplitOnOutage(t *testing.T) {
+	var requests int
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer testServer.Close()
+
+	ctx := context.TODO()
+	client, err := New(ctx,
+		WithHTTPClient(testServer.Client()),
+		WithBaseURL(testServer.URL),
+		WithClientID("testClientID"),
+		WithModel("testModel"),
+		WithTokenSource(StaticTokenSource("test_token")),
+	)
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	var concepts []Concept
+	for i := 0; i < 8; i++ {
+		concepts = append(concepts, Concept{PrefLabel: fmt.Sprintf("Concept %d", i), Type: TypeOrganisation, SchemaObject: ConceptSchemaOrganisation})
+	}
+	results := client.CreateConcepts(ctx, "testTask", concepts, BatchSize(4))
+
+	for _, result := range results {
+		if result.Err == nil {
+			t.Errorf("expected error creating concept %v", result.Concept.PrefLabel)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("unexpected number of requests, got %d, want 2", requests)
+	}
+}
+
+func TestClientCreateConceptsWriteOptions(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("warningsAccepted") != "true" {
+			t.Errorf("expected warnings to be accepted, got query %v", req.URL.RawQuery)
+		}
+	}))
+	defer testServer.Close()
+
+	ctx := context.TODO()
+	client, err := New(ctx,
+		WithHTTPClient(testServer.Client()),
+		WithBaseURL(testServer.URL),
+		WithClientID("testClientID"),
+		WithModel("testModel"),
+		WithTokenSource(StaticTokenSource("test_token")),
+	)
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	concepts := []Concept{{PrefLabel: "Concept", Type: TypeOrganisation, SchemaObject: ConceptSchemaOrganisation}}
+	results := client.CreateConcepts(ctx, "testTask", concepts, BatchSize(1), WithWriteOptions(AcceptWarnings()))
+	if results[0].Err != nil {
+		t.Errorf("unexpected error creating concept: %v", results[0].Err)
+	}
+}
+
+func TestClientApplyMetadataUpdates(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		time.Sleep(5 * time.Millisecond)
+		if strings.Contains(req.URL.RawQuery, "concept-5") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer testServer.Close()
+
+	ctx := context.TODO()
+	client, err := New(ctx,
+		WithHTTPClient(testServer.Client()),
+		WithBaseURL(testServer.URL),
+		WithClientID("testClientID"),
+		WithModel("testModel"),
+		WithTokenSource(StaticTokenSource("test_token")),
+	)
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	var updates []MetadataUpdate
+	for i := 0; i < 20; i++ {
+		updates = append(updates, MetadataUpdate{
+			ConceptID: fmt.Sprintf("concept-%d", i),
+			FieldName: "factsetIdentifier",
+			Value:     StringValue(fmt.Sprintf("factset-%d", i)),
+		})
+	}
+
+	report := client.ApplyMetadataUpdates(ctx, "testTask", updates, Concurrency(3))
+	if report.Err != nil {
+		t.Errorf("unexpected batch error: %v", report.Err)
+	}
+	if report.Succeeded != 19 {
+		t.Errorf("unexpected number of succeeded updates, got %d, want 19", report.Succeeded)
+	}
+	if len(report.Failed) != 1 || report.Failed[0].Index != 5 || report.Failed[0].Update.ConceptID != "concept-5" {
+		t.Errorf("unexpected failed updates: %+v", report.Failed)
+	}
+	if report.Checkpoint != 5 {
+		t.Errorf("unexpected checkpoint, got %d, want 5", report.Checkpoint)
+	}
+	if maxInFlight > 3 {
+		t.Errorf("too many concurrent requests, got %d, want at most 3", maxInFlight)
+	}
+}
+
+func TestClientApplyMetadataUpdatesCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requests := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if requests == 3 {
+			cancel()
+		}
+	}))
+	defer testServer.Close()
+
+	client, err := New(ctx,
+		WithHTTPClient(testServer.Client()),
+		WithBaseURL(testServer.URL),
+		WithClientID("testClientID"),
+		WithModel("testModel"),
+		WithTokenSource(StaticTokenSource("test_token")),
+	)
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	updates := make(chan MetadataUpdate)
+	go func() {
+		defer close(updates)
+		for i := 0; i < 10; i++ {
+			select {
+			case updates <- MetadataUpdate{ConceptID: fmt.Sprintf("concept-%d", i), FieldName: "factsetIdentifier", Value: StringValue("factset")}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	report := client.ApplyMetadataUpdateStream(ctx, "testTask", updates, Concurrency(1))
+	if report.Err == nil {
+		t.Errorf("expected batch error after cancellation")
+	}
+	if report.Checkpoint != 2 && report.Checkpoint != 3 {
+		t.Errorf("unexpected checkpoint, got %d, want 2 or 3", report.Checkpoint)
+	}
+	if report.Succeeded+len(report.Failed) >= 10 {
+		t.Errorf("expected the batch to stop after cancellation, got %+v", report)
+	}
+}
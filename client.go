@@ -10,113 +10,178 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
 )
 
 const (
 	MaxAccessFailures = 3
 
+	// Default prefixes of the concept and metadata field IRIs, see Namespace for using different ones.
 	ConceptURIPrefix    = "http://www.ft.com/thing"
 	MetadataFieldPrefix = "http://www.ft.com/ontology"
+
+	// conceptProperties selects all the concept properties together with the literal forms of its labels,
+	// which otherwise are returned as references to separate label nodes.
+	conceptProperties = "[],skosxl:prefLabel/skosxl:literalForm,skosxl:altLabel/skosxl:literalForm"
 )
 
 type Client struct {
 	httpClient *http.Client
 
-	baseAPIURL  url.URL
-	apiTokenURL url.URL
-	apiKey      string
-	model       string
+	baseAPIURL url.URL
+	model      string
+	userAgent  string
+	namespace  Namespace
+
+	retryPolicy RetryPolicy
+	limiter     *rateLimiter
 
-	accessToken string
+	tokens *tokenManager
 
+	// IgnoreWarnings accepts the Smartlogic warnings on every write request made by the client.
+	//
+	// Deprecated: pass AcceptWarnings to the individual write requests instead.
 	IgnoreWarnings bool
 }
 
+// NewClient creates Client authenticating with the API key. It fetches the first access token straight away,
+// so it fails when Smartlogic is not reachable or the credentials are not valid.
+// Use New for the configuration beyond these arguments.
 func NewClient(ctx context.Context, httpClient *http.Client, baseCloudURL *url.URL, clientID, apiKey, model string) (*Client, error) {
+	tokenSource := APIKeyTokenSource(httpClient, baseCloudURL, apiKey)
+	return NewClientWithTokenSource(ctx, httpClient, baseCloudURL, clientID, model, tokenSource)
+}
+
+// NewClientWithTokenSource creates Client getting its access tokens from the given TokenSource.
+// Passing the same ReuseTokenSource to several Clients makes them share the cached token.
+func NewClientWithTokenSource(ctx context.Context, httpClient *http.Client, baseCloudURL *url.URL, clientID, model string, tokenSource TokenSource) (*Client, error) {
+	client := newClient(httpClient, baseCloudURL, clientID, model, tokenSource)
+
+	_, err := client.tokens.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// newClient creates Client with the default configuration, making no request to Smartlogic.
+func newClient(httpClient *http.Client, baseCloudURL *url.URL, clientID, model string, tokenSource TokenSource) *Client {
 	baseAPIURL := *baseCloudURL
 	baseAPIURL.Path = path.Join(baseCloudURL.Path, fmt.Sprintf("/sw/client/%s/api", clientID))
 
-	apiTokenURL := *baseCloudURL
-	apiTokenURL.Path = path.Join(baseCloudURL.Path, "token")
-
-	client := &Client{
+	return &Client{
 		httpClient:     httpClient,
 		baseAPIURL:     baseAPIURL,
-		apiTokenURL:    apiTokenURL,
-		apiKey:         apiKey,
 		model:          model,
+		tokens:         ReuseTokenSource(tokenSource).(*tokenManager),
+		namespace:      defaultNamespace,
 		IgnoreWarnings: false,
 	}
+}
+
+// Ping checks that Smartlogic is reachable, the client credentials are valid and the model is available,
+// e.g. for the health checks of the services using the client.
+func (c *Client) Ping(ctx context.Context) error {
+	// Construct the request url. It looks like smartlogicURL?path=model:MyModel.
+	reqURL := c.baseAPIURL
+	reqURL.RawQuery = "path=" + url.QueryEscape("model:"+c.model)
 
-	accessToken, err := client.getAccessToken(ctx)
+	resp, err := c.makeAuthorizedRequest(ctx, http.MethodGet, reqURL.String(), nil)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed pinging Smartlogic: %w", err)
 	}
+	defer resp.Body.Close()
 
-	client.accessToken = accessToken
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed pinging Smartlogic: %w", newAPIError(resp))
+	}
 
-	return client, nil
+	return nil
 }
 
 // CreateConcept creates concept under given schema so the input concept should have schema defined.
-func (c *Client) CreateConcept(ctx context.Context, concept Concept, task string) error {
-	if concept.PrefLabel == "" {
-		return errors.New("input concept should have prefLaber defined")
+func (c *Client) CreateConcept(ctx context.Context, concept Concept, task string, opts ...WriteOption) error {
+	if err := validateNewConcept(concept); err != nil {
+		return err
 	}
 
-	if concept.SchemaObject == "" && concept.Broader == "" {
-		return errors.New("input concept should have either schema or broader relation defined")
+	// Construct the request body in the namespace of the client.
+	body, err := json.Marshal(concept.toInput().object(c.namespace))
+	if err != nil {
+		return fmt.Errorf("failed json encoding concept: %w", err)
 	}
 
-	if concept.Type == "" {
-		return errors.New("input concept should have type defined")
-	}
+	return c.postConcepts(ctx, task, body, opts)
+}
 
+// postConcepts sends the request creating the concepts encoded in the body.
+func (c *Client) postConcepts(ctx context.Context, task string, body []byte, opts []WriteOption) error {
 	// Construct the request url. It looks like smartlogicURL?path=task:MyModel:Mytask/skos:Concept/rdf:instance.
 	reqURL := c.baseAPIURL
 	rawQuery := fmt.Sprintf("path=task:%s:%s/skos:Concept/rdf:instance", c.model, task)
-	if c.IgnoreWarnings {
+	if c.warningsAccepted(opts) {
 		rawQuery += "&warningsAccepted=true"
 	}
 	// We don't want to encode the path param here.
 	reqURL.RawQuery = rawQuery
 
-	// Construct the request body, we really on the custom marshalling of the concept object.
-	body, err := json.Marshal(concept)
-	if err != nil {
-		return fmt.Errorf("failed json encoding concept: %w", err)
-	}
-	resp, err := c.makeAuthorizedRequest(ctx, http.MethodPost, reqURL.String(), bytes.NewBuffer(body))
+	resp, err := c.makeAuthorizedRequest(ctx, http.MethodPost, reqURL.String(), body, opts...)
 	if err != nil {
-		return fmt.Errorf("failed creating new concept: %v", err)
+		return fmt.Errorf("failed creating new concept: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed creating new concept, returned status %v", resp.StatusCode)
+		return fmt.Errorf("failed creating new concept: %w", newWriteError(resp))
 	}
 
 	return nil
 }
 
-func (c *Client) AddConceptMetadataField(ctx context.Context, conceptID, fieldName, fieldValue, task string) error {
+// validateNewConcept checks that the concept has all the properties required to create it.
+func validateNewConcept(concept Concept) error {
+	if !concept.hasPrefLabel() {
+		return errors.New("input concept should have prefLaber defined")
+	}
+
+	if err := concept.validateLabels(); err != nil {
+		return err
+	}
+
+	if concept.SchemaObject == "" && concept.Broader == "" {
+		return errors.New("input concept should have either schema or broader relation defined")
+	}
+
+	if concept.Type == "" {
+		return errors.New("input concept should have type defined")
+	}
+
+	return nil
+}
+
+// AddConceptMetadataField adds a plain string value of the metadata field to an existing concept.
+func (c *Client) AddConceptMetadataField(ctx context.Context, conceptID, fieldName, fieldValue, task string, opts ...WriteOption) error {
+	return c.AddConceptMetadataValue(ctx, conceptID, fieldName, StringValue(fieldValue), task, opts...)
+}
+
+// AddConceptMetadataValue adds a typed value of the metadata field to an existing concept.
+// The existing values of the field are kept.
+func (c *Client) AddConceptMetadataValue(ctx context.Context, conceptID, fieldName string, fieldValue MetadataValue, task string, opts ...WriteOption) error {
 	// Construct the request url. It looks like smartlogicURL?path=task:MyModel:Mytask/doubleEncodedConcept.
 	reqURL := c.baseAPIURL
 
-	conceptURI := ConceptURIPrefix + "/" + conceptID
-	// Smartlogic API requires the conceptURI that is part of the path query param to be escaped twice and inside < >.
-	encodedConceptURI := url.QueryEscape(url.QueryEscape(fmt.Sprintf("<%s>", conceptURI)))
-
-	rawQuery := fmt.Sprintf("path=task:%s:%s/%s", c.model, task, encodedConceptURI)
-	if c.IgnoreWarnings {
+	conceptURI := c.namespace.conceptURI(conceptID)
+	rawQuery := "path=" + c.conceptPath(task, conceptURI)
+	if c.warningsAccepted(opts) {
 		rawQuery += "&warningsAccepted=true"
 	}
 	// We don't want to encode the path param here.
 	reqURL.RawQuery = rawQuery
 
 	// Construct the request body.
-	fieldURI := MetadataFieldPrefix + "/" + fieldName
-	bodyMap := map[string]string{
+	fieldURI := c.namespace.fieldURI(fieldName)
+	bodyMap := map[string]interface{}{
 		"@id":    conceptURI,
 		fieldURI: fieldValue,
 	}
@@ -125,113 +190,475 @@ func (c *Client) AddConceptMetadataField(ctx context.Context, conceptID, fieldNa
 		return fmt.Errorf("failed encoding metadata body: %w", err)
 	}
 
-	resp, err := c.makeAuthorizedRequest(ctx, http.MethodPost, reqURL.String(), bytes.NewBuffer(body))
+	resp, err := c.makeAuthorizedRequest(ctx, http.MethodPost, reqURL.String(), body, opts...)
 	if err != nil {
-		return fmt.Errorf("failed adding metadata to concept %s: %v", conceptID, err)
+		return fmt.Errorf("failed adding metadata to concept %s: %w", conceptID, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed adding metadata to concept %s, returned status %v", conceptID, resp.StatusCode)
+		return fmt.Errorf("failed adding metadata to concept %s: %w", conceptID, newWriteError(resp))
 	}
 
 	return nil
 }
 
-func (c *Client) GetConceptsWithCustomMetadata(ctx context.Context, task string, field string, value string) ([]interface{}, error) {
-	params := url.Values{}
-	params.Add("path", path.Join(
-		fmt.Sprintf("task:%s:%s", c.model, task),
-		"skos:Concept",
-		"meta:transitiveInstance",
-	))
-	params.Add("properties", `rdf:type,meta:displayName,[]`)
-	params.Add("filters", fmt.Sprintf(`subject(<%s>="%s")`, field, value))
-	reqURL := c.baseAPIURL
-	reqURL.RawQuery = params.Encode()
+// RemoveConceptMetadataField removes the given value of the metadata field from an existing concept.
+func (c *Client) RemoveConceptMetadataField(ctx context.Context, conceptID, fieldName string, fieldValue MetadataValue, task string, opts ...WriteOption) error {
+	fieldURI := c.namespace.fieldURI(fieldName)
+	patch := conceptPatch{
+		ID: c.namespace.conceptURI(conceptID),
+		Delete: map[string][]MetadataValue{
+			fieldURI: {fieldValue},
+		},
+	}
 
-	resp, err := c.makeAuthorizedRequest(ctx, http.MethodGet, reqURL.String(), nil)
+	return c.patchConcept(ctx, task, conceptID, patch, opts)
+}
+
+// SetConceptMetadataField replaces all the existing values of the metadata field of a concept with the given ones.
+// Passing no values removes the field from the concept.
+func (c *Client) SetConceptMetadataField(ctx context.Context, conceptID, fieldName string, fieldValues []MetadataValue, task string, opts ...WriteOption) error {
+	data, err := c.getConceptNode(ctx, task, conceptID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make search request: %w", err)
+		return err
+	}
+	var node jsonldNode
+	if err = json.Unmarshal(data, &node); err != nil {
+		return fmt.Errorf("failed decoding concept %s: %w", conceptID, err)
 	}
-	defer resp.Body.Close()
 
-	var data struct {
-		Graph []interface{} `json:"@graph"`
+	fieldURI := c.namespace.fieldURI(fieldName)
+	var current metadataValues
+	if err = node.decode(fieldURI, &current); err != nil {
+		return fmt.Errorf("failed decoding concept %s: %w", conceptID, err)
 	}
 
-	err = json.NewDecoder(resp.Body).Decode(&data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read search response: %w", err)
+	patch := conceptPatch{
+		ID: c.namespace.conceptURI(conceptID),
+	}
+	if len(current) > 0 {
+		patch.Delete = map[string][]MetadataValue{fieldURI: []MetadataValue(current)}
+	}
+	if len(fieldValues) > 0 {
+		patch.Insert = map[string][]MetadataValue{fieldURI: fieldValues}
+	}
+	if patch.Delete == nil && patch.Insert == nil {
+		return nil
 	}
 
-	return data.Graph, nil
+	return c.patchConcept(ctx, task, conceptID, patch, opts)
 }
 
-func (c *Client) makeAuthorizedRequest(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
-	for accessFailures := 0; accessFailures < MaxAccessFailures; accessFailures++ {
-		req, err := http.NewRequestWithContext(ctx, method, url, body)
+// UpdateConcept changes the prefLabel, altLabels, description (in all their languages) and identifiers of an existing
+// concept in the task to the values of the given concept. Only the properties which differ from the current state of the concept are
+// replaced, by deleting their old values and inserting the new ones in a single PATCH request.
+// It returns the properties which were changed.
+//
+// IMPORTANT: the given concept replaces the whole state of these properties, so the altLabels, description and
+// identifiers left empty in it are DELETED from the existing concept. To correct a single property, get the concept
+// with GetConcept, change it and pass it back, or change a single identifier with SetConceptMetadataField.
+func (c *Client) UpdateConcept(ctx context.Context, task, conceptID string, concept Concept, opts ...WriteOption) ([]string, error) {
+	if !concept.hasPrefLabel() {
+		return nil, errors.New("input concept should have prefLabel defined")
+	}
+	if err := concept.validateLabels(); err != nil {
+		return nil, err
+	}
+
+	node, err := c.getConceptNode(ctx, task, conceptID)
+	if err != nil {
+		return nil, err
+	}
+	var current Concept
+	if err = current.unmarshalJSON(node, c.namespace); err != nil {
+		return nil, fmt.Errorf("failed decoding concept %s: %w", conceptID, err)
+	}
+	var currentNode jsonldNode
+	if err = json.Unmarshal(node, &currentNode); err != nil {
+		return nil, fmt.Errorf("failed decoding concept %s: %w", conceptID, err)
+	}
+
+	oldInput := current.toInput()
+	newInput := concept.toInput()
+	var del, ins inputConcept
+	var changed []string
+	if current.PrefLabel != concept.PrefLabel || !equalLabels(current.LocalizedPrefLabels, concept.LocalizedPrefLabels) {
+		del.PrefLabel, err = labelsToDelete(currentNode, oldInput.PrefLabel, "skosxl:prefLabel", skosxlPrefix+"prefLabel")
 		if err != nil {
-			return nil, fmt.Errorf("failed creating authorized request: %w", err)
+			return nil, fmt.Errorf("failed decoding concept %s: %w", conceptID, err)
 		}
-		req.Header.Set("Authorization", "Bearer "+c.accessToken)
-		req.Header.Set("Content-Type", "application/ld+json")
-
-		resp, err := c.httpClient.Do(req)
+		ins.PrefLabel = newInput.PrefLabel
+		changed = append(changed, "skosxl:prefLabel")
+	}
+	if !equalStrings(current.AltLabels, concept.AltLabels) || !equalLabels(current.LocalizedAltLabels, concept.LocalizedAltLabels) {
+		del.AltLabels, err = labelsToDelete(currentNode, oldInput.AltLabels, "skosxl:altLabel", skosxlPrefix+"altLabel")
 		if err != nil {
-			return resp, fmt.Errorf("failed making authorized request: %w", err)
+			return nil, fmt.Errorf("failed decoding concept %s: %w", conceptID, err)
 		}
-
-		// We're checking if we got a 401, which would be because the token had expired.
-		// If it has, generate a new one and then make the request again.
-		if resp.StatusCode == http.StatusUnauthorized {
-			accessToken, err := c.getAccessToken(ctx)
-			if err != nil {
-				// close the body of the current request as it won't be read
-				resp.Body.Close()
-				// We got error 401 when making the request and we are not able to receive valid access token.
-				return nil, errors.New("failed making request with valid access token")
-			}
-			c.accessToken = accessToken
-			// close the body of the current request as it won't be read
-			resp.Body.Close()
-			// Try making the request with the fresh access token.
-			continue
+		ins.AltLabels = newInput.AltLabels
+		changed = append(changed, "skosxl:altLabel")
+	}
+	// The description and identifiers are deleted as they are stored rather than as they would be written, so that
+	// untagged values and all of the values of a multi-valued identifier are removed too.
+	var stored []objectField
+	replace := func(key string) {
+		if raw, ok := currentNode[key]; ok {
+			stored = append(stored, objectField{key, raw})
 		}
+		changed = append(changed, key)
+	}
+	if current.Description != concept.Description || !equalLabels(current.LocalizedDescriptions, concept.LocalizedDescriptions) {
+		ins.Description = newInput.Description
+		replace(c.namespace.DescriptionProperty)
+	}
+	if current.TMEIdentifier != concept.TMEIdentifier {
+		ins.TMEIdentifier = newInput.TMEIdentifier
+		replace(c.namespace.TMEIdentifierProperty)
+	}
+	if current.FactsetIdentifier != concept.FactsetIdentifier {
+		ins.FactsetIdentifier = newInput.FactsetIdentifier
+		replace(c.namespace.FactsetIdentifierProperty)
+	}
+	if current.WikidataIdentifier != concept.WikidataIdentifier {
+		ins.WikidataIdentifier = newInput.WikidataIdentifier
+		replace(c.namespace.WikidataIdentifierProperty)
+	}
+	if current.IndustryIdentifier != concept.IndustryIdentifier {
+		ins.IndustryIdentifier = newInput.IndustryIdentifier
+		replace(c.namespace.IndustryIdentifierProperty)
+	}
 
-		return resp, nil
+	if len(changed) == 0 {
+		return nil, nil
 	}
-	return nil, errors.New("failed making request with valid access token")
+
+	patch := conceptPatch{
+		ID: c.namespace.conceptURI(conceptID),
+	}
+	// The properties which were only removed have nothing to insert and the other way round.
+	if obj := append(del.object(c.namespace), stored...); len(obj) > 0 {
+		patch.Delete = obj
+	}
+	if obj := ins.object(c.namespace); len(obj) > 0 {
+		patch.Insert = obj
+	}
+	if err = c.patchConcept(ctx, task, conceptID, patch, opts); err != nil {
+		return nil, err
+	}
+
+	return changed, nil
 }
 
-func (c *Client) getAccessToken(ctx context.Context) (string, error) {
-	data := url.Values{}
-	data.Set("grant_type", "apikey")
-	data.Set("key", c.apiKey)
+// labelsToDelete returns references to the current labels of the concept node stored under the given keys,
+// so that the existing label resources are deleted with all their literal forms. Labels without @id cannot be
+// referred to, so then the labels rebuilt from the decoded concept are returned instead.
+func labelsToDelete(node jsonldNode, rebuilt []conceptLabel, key string, aliases ...string) ([]conceptLabel, error) {
+	refs, ok, err := node.labelRefs(key, aliases...)
+	if err != nil || !ok {
+		return rebuilt, err
+	}
+	return refs, nil
+}
+
+// DeprecateConcept marks an existing concept in the task as deprecated.
+func (c *Client) DeprecateConcept(ctx context.Context, task, conceptID string, opts ...WriteOption) error {
+	patch := conceptPatch{
+		ID:     c.namespace.conceptURI(conceptID),
+		Delete: inputConcept{IsDeprecated: []bool{false}}.object(c.namespace),
+		Insert: inputConcept{IsDeprecated: []bool{true}}.object(c.namespace),
+	}
+
+	return c.patchConcept(ctx, task, conceptID, patch, opts)
+}
+
+// AddRelation links an existing concept in the task to the concept with the given IRI by the relation property,
+// e.g. skos:related or http://www.ft.com/ontology/hasParentOrganisation. The existing relations are kept.
+func (c *Client) AddRelation(ctx context.Context, task, conceptID, relation, relatedURI string, opts ...WriteOption) error {
+	patch := conceptPatch{
+		ID:     c.namespace.conceptURI(conceptID),
+		Insert: relationInput(relation, relatedURI).object(c.namespace),
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiTokenURL.String(), bytes.NewBufferString(data.Encode()))
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.patchConcept(ctx, task, conceptID, patch, opts)
+}
+
+// RemoveRelation removes the link by the relation property between an existing concept in the task
+// and the concept with the given IRI.
+func (c *Client) RemoveRelation(ctx context.Context, task, conceptID, relation, relatedURI string, opts ...WriteOption) error {
+	patch := conceptPatch{
+		ID:     c.namespace.conceptURI(conceptID),
+		Delete: relationInput(relation, relatedURI).object(c.namespace),
+	}
+
+	return c.patchConcept(ctx, task, conceptID, patch, opts)
+}
+
+// DeleteConcept removes an existing concept from the task.
+// If Smartlogic refuses to remove the concept because of its relationships, ConceptRelationshipsError is returned.
+func (c *Client) DeleteConcept(ctx context.Context, task, conceptID string, opts ...WriteOption) error {
+	// Construct the request url. It looks like smartlogicURL?path=task:MyModel:Mytask/doubleEncodedConcept.
+	reqURL := c.baseAPIURL
+
+	rawQuery := "path=" + c.conceptPath(task, c.namespace.conceptURI(conceptID))
+	if c.warningsAccepted(opts) {
+		rawQuery += "&warningsAccepted=true"
+	}
+	// We don't want to encode the path param here.
+	reqURL.RawQuery = rawQuery
+
+	resp, err := c.makeAuthorizedRequest(ctx, http.MethodDelete, reqURL.String(), nil, opts...)
+	if err != nil {
+		return fmt.Errorf("failed deleting concept %s: %w", conceptID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed deleting concept %s: %w", conceptID, newDeleteConceptError(conceptID, resp))
+	}
+
+	return nil
+}
+
+// patchConcept sends the delete and insert changes for the concept with the given UUID in a single PATCH request.
+func (c *Client) patchConcept(ctx context.Context, task, conceptID string, patch conceptPatch, opts []WriteOption) error {
+	// Construct the request url. It looks like smartlogicURL?path=task:MyModel:Mytask/doubleEncodedConcept.
+	reqURL := c.baseAPIURL
+
+	rawQuery := "path=" + c.conceptPath(task, patch.ID)
+	if c.warningsAccepted(opts) {
+		rawQuery += "&warningsAccepted=true"
+	}
+	// We don't want to encode the path param here.
+	reqURL.RawQuery = rawQuery
+
+	body, err := json.Marshal(patch)
 	if err != nil {
-		return "", fmt.Errorf("failed creating access token request: %w", err)
+		return fmt.Errorf("failed json encoding concept patch: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.makeAuthorizedRequest(ctx, http.MethodPatch, reqURL.String(), body, opts...)
 	if err != nil {
-		return "", fmt.Errorf("failed making access token request: %w", err)
+		return fmt.Errorf("failed updating concept %s: %w", conceptID, err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("access token request returned http status %v", resp.StatusCode)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed updating concept %s: %w", conceptID, newWriteError(resp))
 	}
 
-	tokenResp := struct {
-		AccessToken string `json:"access_token"`
-	}{}
-	decoder := json.NewDecoder(resp.Body)
-	err = decoder.Decode(&tokenResp)
+	return nil
+}
+
+// GetConcept fetches the concept with the given UUID from the task and decodes it into Concept.
+func (c *Client) GetConcept(ctx context.Context, task, conceptID string) (Concept, error) {
+	node, err := c.getConceptNode(ctx, task, conceptID)
 	if err != nil {
-		return "", fmt.Errorf("failed decoding access token in response body: %w", err)
+		return Concept{}, err
+	}
+
+	var concept Concept
+	if err = concept.unmarshalJSON(node, c.namespace); err != nil {
+		return Concept{}, fmt.Errorf("failed decoding concept %s: %w", conceptID, err)
+	}
+	return concept, nil
+}
+
+// getConceptNode fetches the undecoded JSON-LD node of the concept with the given UUID from the task.
+func (c *Client) getConceptNode(ctx context.Context, task, conceptID string) (json.RawMessage, error) {
+	// Construct the request url. It looks like smartlogicURL?path=task:MyModel:Mytask/doubleEncodedConcept&properties=....
+	reqURL := c.baseAPIURL
+
+	conceptURI := c.namespace.conceptURI(conceptID)
+	reqURL.RawQuery = c.TaskQuery(task).
+		Resource(conceptURI).
+		Properties(conceptProperties).
+		Encode()
+
+	resp, err := c.makeAuthorizedRequest(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting concept %s: %w", conceptID, err)
+	}
+	defer resp.Body.Close()
+
+	graph, err := readGraph(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting concept %s: %w", conceptID, err)
+	}
+
+	// The graph may contain the label nodes as well, so we are looking for the node describing the concept itself.
+	for _, node := range graph {
+		var nodeID struct {
+			ID string `json:"@id"`
+		}
+		if err = json.Unmarshal(node, &nodeID); err != nil {
+			return nil, fmt.Errorf("failed to read concept response: %w", err)
+		}
+		if nodeID.ID == conceptURI {
+			return node, nil
+		}
+	}
+
+	return nil, fmt.Errorf("concept %s: %w", conceptID, ErrNotFound)
+}
+
+// GetConceptsWithCustomMetadata returns summaries of all concepts in the task which have the given value of the
+// custom metadata field. All the result pages are loaded into memory, use IterateConceptsWithCustomMetadata
+// for large result sets.
+func (c *Client) GetConceptsWithCustomMetadata(ctx context.Context, task string, field string, value string) ([]ConceptSummary, error) {
+	var summaries []ConceptSummary
+	it := c.IterateConceptsWithCustomMetadata(ctx, task, field, value, DefaultPageSize)
+	for it.Next() {
+		summaries = append(summaries, it.Concept())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
+// GetConceptsWithCustomMetadataRaw is like GetConceptsWithCustomMetadata, but returns the undecoded @graph nodes
+// for callers who need properties not available in ConceptSummary.
+func (c *Client) GetConceptsWithCustomMetadataRaw(ctx context.Context, task string, field string, value string) ([]interface{}, error) {
+	var nodes []interface{}
+	it := c.IterateConceptsWithCustomMetadata(ctx, task, field, value, DefaultPageSize)
+	for it.next() {
+		var n interface{}
+		if err := json.Unmarshal(it.node, &n); err != nil {
+			return nil, fmt.Errorf("failed to read search response: %w", err)
+		}
+		nodes = append(nodes, n)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// IterateConceptsWithCustomMetadata returns iterator over all concepts in the task which have the given value of the
+// custom metadata field. The concepts are requested in pages of the given size as the iteration goes.
+func (c *Client) IterateConceptsWithCustomMetadata(ctx context.Context, task string, field string, value string, pageSize int) *ConceptIterator {
+	query := c.TaskQuery(task).
+		Path("skos:Concept", "meta:transitiveInstance").
+		Properties("rdf:type", "meta:displayName", "[]").
+		Filter(Equals(field, value))
+
+	return newConceptIterator(ctx, c, query, field, pageSize)
+}
+
+// IterateConcepts returns iterator over the concepts matching the query, requested in pages of the given size.
+// The limit and offset of the query bound the whole iteration, not the single pages.
+func (c *Client) IterateConcepts(ctx context.Context, query *Query, pageSize int) *ConceptIterator {
+	return newConceptIterator(ctx, c, query, "", pageSize)
+}
+
+// searchConcepts makes the search request with the given query and returns the nodes of the @graph.
+func (c *Client) searchConcepts(ctx context.Context, query *Query) ([]json.RawMessage, error) {
+	if err := query.Err(); err != nil {
+		return nil, fmt.Errorf("failed searching concepts: %w", err)
+	}
+	reqURL := c.baseAPIURL
+	reqURL.RawQuery = query.Encode()
+
+	resp, err := c.makeAuthorizedRequest(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make search request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	graph, err := readGraph(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed searching concepts: %w", err)
+	}
+
+	return graph, nil
+}
+
+// RateLimitStats returns the counters of the requests of the given class which passed through the rate limiter.
+// They are zero when the client has no rate limits.
+func (c *Client) RateLimitStats(class OperationClass) RateLimitStats {
+	if c.limiter == nil {
+		return RateLimitStats{}
+	}
+	return c.limiter.Stats(class)
+}
+
+// warningsAccepted reports whether the Smartlogic warnings should be accepted for a write request with the given options.
+func (c *Client) warningsAccepted(opts []WriteOption) bool {
+	return c.IgnoreWarnings || newWriteOptions(opts).acceptWarnings
+}
+
+// conceptPath returns the value of the path query param pointing to the given concept in the task.
+// Smartlogic API requires the conceptURI that is part of the path query param to be escaped twice and inside < >.
+func (c *Client) conceptPath(task, conceptURI string) string {
+	return strings.TrimPrefix(c.TaskQuery(task).Resource(conceptURI).Encode(), "path=")
+}
+
+// makeAuthorizedRequest makes the request with the current access token, refreshing the token when it has expired.
+// The body is given as bytes, so that the identical payload is sent again when the request is retried.
+// Transient failures are retried according to the retry policy of the client, if the request is idempotent
+// or the write is marked with RetrySafe.
+func (c *Client) makeAuthorizedRequest(ctx context.Context, method, url string, body []byte, opts ...WriteOption) (*http.Response, error) {
+	retryable := isIdempotent(method) || newWriteOptions(opts).retrySafe
+	accessFailures := 0
+	retries := 0
+	for {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating authorized request: %w", err)
+		}
+		if c.limiter != nil {
+			if err = c.limiter.Wait(ctx, operationClass(method)); err != nil {
+				return nil, fmt.Errorf("failed waiting for rate limiter: %w", err)
+			}
+		}
+		token, err := c.tokens.Token(ctx)
+		if err != nil {
+			// We are not able to receive valid access token.
+			return nil, fmt.Errorf("failed making request with valid access token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+		req.Header.Set("Content-Type", "application/ld+json")
+		if c.userAgent != "" {
+			req.Header.Set("User-Agent", c.userAgent)
+		}
+
+		resp, err := c.httpClient.Do(req)
+
+		// We're checking if we got a 401, which would be because the token had expired.
+		// If it has, drop it so a new one is generated and then make the request again.
+		if err == nil && resp.StatusCode == http.StatusUnauthorized {
+			c.tokens.Invalidate(token.AccessToken)
+			// close the body of the current request as it won't be read
+			resp.Body.Close()
+			accessFailures++
+			if accessFailures >= MaxAccessFailures {
+				return nil, fmt.Errorf("failed making request with valid access token: %w", ErrUnauthorized)
+			}
+			// Try making the request with the fresh access token.
+			continue
+		}
+
+		if retryable && retries < c.retryPolicy.MaxRetries && isTransientFailure(resp, err) {
+			if wait, ok := c.retryPolicy.backoff(retries, resp); ok && waitForRetry(ctx, wait) {
+				if err == nil {
+					// discard the body of the current request as it won't be read
+					discardBody(resp)
+				}
+				retries++
+				continue
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed making authorized request: %w", err)
+		}
+		return resp, nil
 	}
-	return tokenResp.AccessToken, nil
 }
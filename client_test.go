@@ -3,10 +3,12 @@ package smartlogic
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"testing"
 )
 
@@ -209,11 +211,13 @@ func TestClientCreateConceptRequestURIAndBody(t *testing.T) {
 
 func TestClientCreateConcept(t *testing.T) {
 	tests := []struct {
-		name           string
-		serverHandler  http.HandlerFunc
-		concept        Concept
-		expectedError  bool
-		ignoreWarnings bool
+		name             string
+		serverHandler    http.HandlerFunc
+		concept          Concept
+		expectedError    bool
+		ignoreWarnings   bool
+		opts             []WriteOption
+		expectedWarnings bool
 	}{
 		{
 			name: "success with 200",
@@ -261,6 +265,42 @@ func TestClientCreateConcept(t *testing.T) {
 			ignoreWarnings: true,
 			expectedError:  false,
 		},
+		{
+			name: "accepting warnings per request",
+			serverHandler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == "/token" {
+					handleTokenRequest(t, w)
+				}
+				if req.URL.Query().Get("warningsAccepted") != "true" {
+					w.WriteHeader(http.StatusConflict)
+				}
+			}),
+			concept: Concept{
+				PrefLabel:    "Test Pref Label",
+				Type:         "Test Type",
+				SchemaObject: "Test Concept Schema",
+			},
+			opts:          []WriteOption{AcceptWarnings()},
+			expectedError: false,
+		},
+		{
+			name: "rejected because of warnings",
+			serverHandler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == "/token" {
+					handleTokenRequest(t, w)
+					return
+				}
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"warnings":[{"code":"DUPLICATE_LABEL","message":"Label Test Pref Label is already used"}]}`))
+			}),
+			concept: Concept{
+				PrefLabel:    "Test Pref Label",
+				Type:         "Test Type",
+				SchemaObject: "Test Concept Schema",
+			},
+			expectedError:    true,
+			expectedWarnings: true,
+		},
 		{
 			name: "invalid concept - no concept pref label",
 			serverHandler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -348,13 +388,799 @@ func TestClientCreateConcept(t *testing.T) {
 				t.Fatalf("failed creating Smartlogic client: %v", err)
 			}
 			client.IgnoreWarnings = test.ignoreWarnings
-			err = client.CreateConcept(ctx, test.concept, "testTask")
+			err = client.CreateConcept(ctx, test.concept, "testTask", test.opts...)
 			if err != nil && !test.expectedError {
 				t.Errorf("unexpected error adding new concept: %v", err)
 			}
 			if err == nil && test.expectedError {
 				t.Errorf("expected error adding new concept")
 			}
+			var warningsErr *WarningsError
+			if errors.As(err, &warningsErr) != test.expectedWarnings {
+				t.Errorf("unexpected error type returned: %v", err)
+			}
+			if test.expectedWarnings && warningsErr.Warnings[0].Code != "DUPLICATE_LABEL" {
+				t.Errorf("unexpected warnings returned: %+v", warningsErr.Warnings)
+			}
+			testServer.Close()
+		})
+	}
+}
+
+func TestClientGetConcept(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			if req.URL.Path == "/sw/client/testClientID/api" &&
+				req.URL.RawQuery == "path=task:testModel:testTask/%253Chttp%253A%252F%252Fwww.ft.com%252Fthing%252F7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0%253E&properties=%5B%5D%2Cskosxl%3AprefLabel%2Fskosxl%3AliteralForm%2Cskosxl%3AaltLabel%2Fskosxl%3AliteralForm" {
+				w.Header().Set("Content-Type", "application/ld+json")
+				_, err := w.Write([]byte(`{"@graph":[{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","@type":["skos:Concept","http://www.ft.com/ontology/organisation/Organisation"],"skosxl:prefLabel":[{"skosxl:literalForm":[{"@value":"Apple Inc","@language":"en"}]}],"skosxl:altLabel":[{"skosxl:literalForm":[{"@value":"Apple","@language":"en"}]}],"skos:topConceptOf":[{"@id":"http://www.ft.com/ontology/scheme/Organisations"}],"http://www.ft.com/ontology/factsetIdentifier":[{"@value":"000C7F-E"}]}]}`))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	concept, err := client.GetConcept(ctx, "testTask", "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0")
+	if err != nil {
+		t.Fatalf("failed getting concept: %v", err)
+	}
+	expected := Concept{
+		ID:                "http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0",
+		PrefLabel:         "Apple Inc",
+		AltLabels:         []string{"Apple"},
+		Type:              TypeOrganisation,
+		SchemaObject:      ConceptSchemaOrganisation,
+		FactsetIdentifier: "000C7F-E",
+	}
+	if !reflect.DeepEqual(concept, expected) {
+		t.Errorf("unexpected concept returned, got %+v, want %+v", concept, expected)
+	}
+
+	_, err = client.GetConcept(ctx, "testTask", "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found error getting missing concept, got %v", err)
+	}
+}
+
+func TestClientGetConceptsWithCustomMetadata(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			if req.URL.Query().Get("filters") != `subject(<http://www.ft.com/ontology/factsetIdentifier>="000C7F-E")` {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/ld+json")
+			_, err := w.Write([]byte(`{"@graph":[{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","@type":["skos:Concept","http://www.ft.com/ontology/organisation/Organisation"],"meta:displayName":"Apple Inc","http://www.ft.com/ontology/factsetIdentifier":[{"@value":"000C7F-E"}]}]}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	summaries, err := client.GetConceptsWithCustomMetadata(ctx, "testTask", "http://www.ft.com/ontology/factsetIdentifier", "000C7F-E")
+	if err != nil {
+		t.Fatalf("failed getting concepts with custom metadata: %v", err)
+	}
+	expected := []ConceptSummary{{
+		ID:          "http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0",
+		Type:        TypeOrganisation,
+		DisplayName: "Apple Inc",
+		FieldValues: []string{"000C7F-E"},
+	}}
+	if !reflect.DeepEqual(summaries, expected) {
+		t.Errorf("unexpected concepts returned, got %+v, want %+v", summaries, expected)
+	}
+
+	raw, err := client.GetConceptsWithCustomMetadataRaw(ctx, "testTask", "http://www.ft.com/ontology/factsetIdentifier", "000C7F-E")
+	if err != nil {
+		t.Fatalf("failed getting raw concepts with custom metadata: %v", err)
+	}
+	if len(raw) != 1 {
+		t.Errorf("unexpected number of raw concepts returned, got %d, want 1", len(raw))
+	}
+}
+
+func TestClientUpdateConcept(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			switch req.Method {
+			case http.MethodGet:
+				w.Header().Set("Content-Type", "application/ld+json")
+				_, err := w.Write([]byte(`{"@graph":[{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","@type":["skos:Concept","http://www.ft.com/ontology/organisation/Organisation"],"skosxl:prefLabel":[{"skosxl:literalForm":[{"@value":"Apple Inc","@language":"en"}]}],"http://www.ft.com/ontology/factsetIdentifier":[{"@value":"WRONG-E"}]}]}`))
+				if err != nil {
+					t.Fatal(err)
+				}
+			case http.MethodPatch:
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Errorf("invalid body send on update concept: %v", err)
+				}
+				if string(body) != `{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","sem:delete":{"http://www.ft.com/ontology/factsetIdentifier":[{"@value":"WRONG-E"}]},"sem:insert":{"skosxl:altLabel":[{"skosxl:literalForm":[{"@value":"Apple","@language":"en"}],"@type":["skosxl:Label"]}],"http://www.ft.com/ontology/factsetIdentifier":[{"@value":"000C7F-E"}]}}` {
+					t.Errorf("invalid body send on update concept: got %v", string(body))
+				}
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	changed, err := client.UpdateConcept(ctx, "testTask", "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0", Concept{
+		PrefLabel:         "Apple Inc",
+		AltLabels:         []string{"Apple"},
+		FactsetIdentifier: "000C7F-E",
+	})
+	if err != nil {
+		t.Fatalf("failed updating concept: %v", err)
+	}
+	expected := []string{"skosxl:altLabel", "http://www.ft.com/ontology/factsetIdentifier"}
+	if !reflect.DeepEqual(changed, expected) {
+		t.Errorf("unexpected changed properties, got %v, want %v", changed, expected)
+	}
+}
+
+func TestClientUpdateConceptRemovesAltLabel(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			switch req.Method {
+			case http.MethodGet:
+				w.Header().Set("Content-Type", "application/ld+json")
+				_, err := w.Write([]byte(`{"@graph":[{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","@type":["skos:Concept","http://www.ft.com/ontology/organisation/Organisation"],"skosxl:prefLabel":[{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0_prefLabel","skosxl:literalForm":[{"@value":"Apple Inc","@language":"en"},{"@value":"Apple SA","@language":"fr"}]}],"skosxl:altLabel":[{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0_altLabel","skosxl:literalForm":[{"@value":"Apple","@language":"en"},{"@value":"Pomme","@language":"fr"}]}]}]}`))
+				if err != nil {
+					t.Fatal(err)
+				}
+			case http.MethodPatch:
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Errorf("invalid body send on update concept: %v", err)
+				}
+				if string(body) != `{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","sem:delete":{"skosxl:altLabel":[{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0_altLabel"}]}}` {
+					t.Errorf("invalid body send on update concept: got %v", string(body))
+				}
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	changed, err := client.UpdateConcept(ctx, "testTask", "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0", Concept{
+		PrefLabel:           "Apple Inc",
+		LocalizedPrefLabels: []Label{{Value: "Apple SA", Language: "fr"}},
+	})
+	if err != nil {
+		t.Fatalf("failed updating concept: %v", err)
+	}
+	expected := []string{"skosxl:altLabel"}
+	if !reflect.DeepEqual(changed, expected) {
+		t.Errorf("unexpected changed properties, got %v, want %v", changed, expected)
+	}
+}
+
+func TestClientUpdateConceptReplacesAllProperties(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			switch req.Method {
+			case http.MethodGet:
+				w.Header().Set("Content-Type", "application/ld+json")
+				_, err := w.Write([]byte(`{"@graph":[{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","@type":["skos:Concept"],"skosxl:prefLabel":[{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0_prefLabel","skosxl:literalForm":[{"@value":"Apple Inc","@language":"en"}]}],"http://www.ft.com/ontology/description":{"@value":"Maker of phones"},"http://www.ft.com/ontology/TMEIdentifier":[{"@value":"TME-1"}],"http://www.ft.com/ontology/wikidataIdentifier":[{"@value":"http://www.wikidata.org/entity/Q312","@type":"xsd:anyURI"},{"@value":"http://www.wikidata.org/entity/Q313","@type":"xsd:anyURI"}]}]}`))
+				if err != nil {
+					t.Fatal(err)
+				}
+			case http.MethodPatch:
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Errorf("invalid body send on update concept: %v", err)
+				}
+				if string(body) != `{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","sem:delete":{"http://www.ft.com/ontology/description":{"@value":"Maker of phones"},"http://www.ft.com/ontology/TMEIdentifier":[{"@value":"TME-1"}],"http://www.ft.com/ontology/wikidataIdentifier":[{"@value":"http://www.wikidata.org/entity/Q312","@type":"xsd:anyURI"},{"@value":"http://www.wikidata.org/entity/Q313","@type":"xsd:anyURI"}]},"sem:insert":{"http://www.ft.com/ontology/factsetIdentifier":[{"@value":"F2"}]}}` {
+					t.Errorf("invalid body send on update concept: got %v", string(body))
+				}
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	// The description and identifiers missing from the given concept are deleted.
+	changed, err := client.UpdateConcept(ctx, "testTask", "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0", Concept{
+		PrefLabel:         "Apple Inc",
+		FactsetIdentifier: "F2",
+	})
+	if err != nil {
+		t.Fatalf("failed updating concept: %v", err)
+	}
+	expected := []string{
+		"http://www.ft.com/ontology/description",
+		"http://www.ft.com/ontology/TMEIdentifier",
+		"http://www.ft.com/ontology/factsetIdentifier",
+		"http://www.ft.com/ontology/wikidataIdentifier",
+	}
+	if !reflect.DeepEqual(changed, expected) {
+		t.Errorf("unexpected changed properties, got %v, want %v", changed, expected)
+	}
+}
+
+func TestClientDeprecateConcept(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			if req.Method != http.MethodPatch {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				t.Errorf("invalid body send on deprecate concept: %v", err)
+			}
+			if string(body) != `{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","sem:delete":{"http://www.ft.com/ontology/isDeprecated":[false]},"sem:insert":{"http://www.ft.com/ontology/isDeprecated":[true]}}` {
+				t.Errorf("invalid body send on deprecate concept: got %v", string(body))
+			}
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	err = client.DeprecateConcept(ctx, "testTask", "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0")
+	if err != nil {
+		t.Errorf("failed deprecating concept: %v", err)
+	}
+}
+
+func TestClientDeprecateConceptConflict(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"errors":[{"code":"DUPLICATE_LABEL","message":"duplicate label"}]}`))
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	err = client.DeprecateConcept(ctx, "testTask", "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0")
+	var relationsErr *ConceptRelationshipsError
+	if errors.As(err, &relationsErr) {
+		t.Errorf("unexpected concept relationships error on conflicting update: %v", err)
+	}
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("expected conflict error, got %v", err)
+	}
+}
+
+func TestClientAddAndRemoveRelation(t *testing.T) {
+	expectedBodies := []string{
+		`{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","sem:insert":{"http://www.ft.com/ontology/hasParentOrganisation":[{"@id":"http://www.ft.com/thing/2a6e3b6e-7a6c-3b14-9c2e-d6bc6e5c8f5d"}]}}`,
+		`{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","sem:delete":{"http://www.ft.com/ontology/hasParentOrganisation":[{"@id":"http://www.ft.com/thing/2a6e3b6e-7a6c-3b14-9c2e-d6bc6e5c8f5d"}]}}`,
+	}
+	var requests int
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			if req.Method != http.MethodPatch {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				t.Errorf("invalid body send on relation change: %v", err)
+			}
+			if requests >= len(expectedBodies) || string(body) != expectedBodies[requests] {
+				t.Errorf("invalid body send on relation change: got %v", string(body))
+			}
+			requests++
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	err = client.AddRelation(ctx, "testTask", "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0", "http://www.ft.com/ontology/hasParentOrganisation", "http://www.ft.com/thing/2a6e3b6e-7a6c-3b14-9c2e-d6bc6e5c8f5d")
+	if err != nil {
+		t.Errorf("failed adding relation: %v", err)
+	}
+	err = client.RemoveRelation(ctx, "testTask", "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0", "http://www.ft.com/ontology/hasParentOrganisation", "http://www.ft.com/thing/2a6e3b6e-7a6c-3b14-9c2e-d6bc6e5c8f5d")
+	if err != nil {
+		t.Errorf("failed removing relation: %v", err)
+	}
+}
+
+func TestClientDeleteConcept(t *testing.T) {
+	tests := []struct {
+		name              string
+		serverHandler     http.HandlerFunc
+		expectedError     bool
+		expectedRelations bool
+		ignoreWarnings    bool
+	}{
+		{
+			name: "success",
+			serverHandler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == "/token" {
+					handleTokenRequest(t, w)
+					return
+				}
+				if req.Method != http.MethodDelete {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+				}
+			}),
+			expectedError: false,
+		},
+		{
+			name: "sending ignore warning",
+			serverHandler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == "/token" {
+					handleTokenRequest(t, w)
+					return
+				}
+				if req.URL.Query().Get("warningsAccepted") != "true" {
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}),
+			ignoreWarnings: true,
+			expectedError:  false,
+		},
+		{
+			name: "existing relationships",
+			serverHandler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == "/token" {
+					handleTokenRequest(t, w)
+					return
+				}
+				w.WriteHeader(http.StatusConflict)
+			}),
+			expectedError:     true,
+			expectedRelations: true,
+		},
+		{
+			name: "non 200 response",
+			serverHandler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == "/token" {
+					handleTokenRequest(t, w)
+					return
+				}
+				w.WriteHeader(http.StatusInternalServerError)
+			}),
+			expectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testServer := httptest.NewServer(test.serverHandler)
+			serverURL, err := url.Parse(testServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.TODO()
+
+			client, err := NewClient(ctx, testServer.Client(), serverURL, "test", "test", "test")
+			if err != nil {
+				t.Fatalf("failed creating Smartlogic client: %v", err)
+			}
+			client.IgnoreWarnings = test.ignoreWarnings
+			err = client.DeleteConcept(ctx, "testTask", "conceptID")
+			if err != nil && !test.expectedError {
+				t.Errorf("unexpected error deleting concept: %v", err)
+			}
+			if err == nil && test.expectedError {
+				t.Errorf("expected error deleting concept")
+			}
+			var relationsErr *ConceptRelationshipsError
+			if errors.As(err, &relationsErr) != test.expectedRelations {
+				t.Errorf("unexpected error type returned: %v", err)
+			}
+			testServer.Close()
+		})
+	}
+}
+
+func TestClientSetConceptMetadataField(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			switch req.Method {
+			case http.MethodGet:
+				w.Header().Set("Content-Type", "application/ld+json")
+				_, err := w.Write([]byte(`{"@graph":[{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","@type":["skos:Concept","http://www.ft.com/ontology/organisation/Organisation"],"http://www.ft.com/ontology/factsetIdentifier":[{"@value":"WRONG-E"},{"@value":"OTHER-E"}]}]}`))
+				if err != nil {
+					t.Fatal(err)
+				}
+			case http.MethodPatch:
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Errorf("invalid body send on set concept metadata: %v", err)
+				}
+				if string(body) != `{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","sem:delete":{"http://www.ft.com/ontology/factsetIdentifier":["WRONG-E","OTHER-E"]},"sem:insert":{"http://www.ft.com/ontology/factsetIdentifier":["0DR49W-E"]}}` {
+					t.Errorf("invalid body send on set concept metadata: got %v", string(body))
+				}
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	err = client.SetConceptMetadataField(ctx, "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0", "factsetIdentifier", []MetadataValue{StringValue("0DR49W-E")}, "testTask")
+	if err != nil {
+		t.Errorf("failed setting concept metadata field: %v", err)
+	}
+}
+
+func TestClientChangeConceptMetadataField(t *testing.T) {
+	tests := []struct {
+		name          string
+		currentNode   string
+		change        func(ctx context.Context, client *Client) error
+		expectedPatch string
+	}{
+		{
+			name:        "set field with single current value",
+			currentNode: `{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","@type":["skos:Concept","http://www.ft.com/ontology/organisation/Organisation"],"http://www.ft.com/ontology/factsetIdentifier":{"@value":"WRONG-E"}}`,
+			change: func(ctx context.Context, client *Client) error {
+				return client.SetConceptMetadataField(ctx, "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0", "factsetIdentifier", []MetadataValue{StringValue("0DR49W-E")}, "testTask")
+			},
+			expectedPatch: `{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","sem:delete":{"http://www.ft.com/ontology/factsetIdentifier":["WRONG-E"]},"sem:insert":{"http://www.ft.com/ontology/factsetIdentifier":["0DR49W-E"]}}`,
+		},
+		{
+			name: "remove field value",
+			change: func(ctx context.Context, client *Client) error {
+				return client.RemoveConceptMetadataField(ctx, "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0", "wikidataIdentifier", URIValue("http://www.wikidata.org/entity/Q312"), "testTask")
+			},
+			expectedPatch: `{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","sem:delete":{"http://www.ft.com/ontology/wikidataIdentifier":[{"@value":"http://www.wikidata.org/entity/Q312","@type":"xsd:anyURI"}]}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var patched bool
+			testServer := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					if req.URL.Path == "/token" {
+						handleTokenRequest(t, w)
+						return
+					}
+					switch req.Method {
+					case http.MethodGet:
+						w.Header().Set("Content-Type", "application/ld+json")
+						_, err := w.Write([]byte(`{"@graph":[` + test.currentNode + `]}`))
+						if err != nil {
+							t.Fatal(err)
+						}
+					case http.MethodPatch:
+						patched = true
+						body, err := ioutil.ReadAll(req.Body)
+						if err != nil {
+							t.Errorf("invalid body send on metadata change: %v", err)
+						}
+						if string(body) != test.expectedPatch {
+							t.Errorf("invalid body send on metadata change: got %v", string(body))
+						}
+					default:
+						w.WriteHeader(http.StatusMethodNotAllowed)
+					}
+				}))
+			defer testServer.Close()
+
+			serverURL, err := url.Parse(testServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.TODO()
+
+			client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+			if err != nil {
+				t.Fatalf("failed creating Smartlogic client: %v", err)
+			}
+
+			if err = test.change(ctx, client); err != nil {
+				t.Errorf("failed changing concept metadata field: %v", err)
+			}
+			if !patched {
+				t.Error("expected concept to be patched")
+			}
+		})
+	}
+}
+
+func TestClientWriteErrors(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	concept := Concept{PrefLabel: "Apple Inc", Type: TypeOrganisation, SchemaObject: ConceptSchemaOrganisation}
+	err = client.CreateConcept(ctx, concept, "testTask")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected unauthorized error creating concept, got %v", err)
+	}
+	err = client.DeleteConcept(ctx, "testTask", "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected unauthorized error deleting concept, got %v", err)
+	}
+	err = client.DeprecateConcept(ctx, "testTask", "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected unauthorized error deprecating concept, got %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = client.AddConceptMetadataField(cancelled, "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0", "factsetIdentifier", "000C7F-E", "testTask")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancelled error adding metadata, got %v", err)
+	}
+}
+
+func TestClientRetriesRequestBodyAfterTokenRefresh(t *testing.T) {
+	expectedBody := `{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","http://www.ft.com/ontology/factsetIdentifier":"0DR49W-E"}`
+	var requests []string
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				t.Errorf("failed reading request body: %v", err)
+			}
+			requests = append(requests, string(body))
+			// The first write is rejected as if the access token had expired.
+			if len(requests) == 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	err = client.AddConceptMetadataField(ctx, "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0", "factsetIdentifier", "0DR49W-E", "testTask")
+	if err != nil {
+		t.Fatalf("failed adding concept metadata field: %v", err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("unexpected number of requests, got %d, want 2", len(requests))
+	}
+	for i, body := range requests {
+		if body != expectedBody {
+			t.Errorf("unexpected body of request %d, got %v, want %v", i, body, expectedBody)
+		}
+	}
+}
+
+func TestClientPing(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverHandler http.HandlerFunc
+		expectedError bool
+	}{
+		{
+			name: "success",
+			serverHandler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == "/token" {
+					handleTokenRequest(t, w)
+					return
+				}
+				if req.URL.Query().Get("path") != "model:testModel" {
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}),
+			expectedError: false,
+		},
+		{
+			name: "cannot get access token",
+			serverHandler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			}),
+			expectedError: true,
+		},
+		{
+			name: "smartlogic unavailable",
+			serverHandler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == "/token" {
+					handleTokenRequest(t, w)
+					return
+				}
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}),
+			expectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests int
+			testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				requests++
+				test.serverHandler(w, req)
+			}))
+			serverURL, err := url.Parse(testServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client, err := New(context.TODO(),
+				WithHTTPClient(testServer.Client()),
+				WithBaseURL(serverURL.String()),
+				WithClientID("testClientID"),
+				WithAPIKey("testAPIKey"),
+				WithModel("testModel"),
+				WithLazyTokens(),
+			)
+			if err != nil {
+				t.Fatalf("failed creating Smartlogic client: %v", err)
+			}
+			if requests != 0 {
+				t.Errorf("unexpected requests made when creating lazy client: %d", requests)
+			}
+
+			err = client.Ping(context.TODO())
+			if err != nil && !test.expectedError {
+				t.Errorf("unexpected error pinging Smartlogic: %v", err)
+			}
+			if err == nil && test.expectedError {
+				t.Errorf("expected error pinging Smartlogic")
+			}
 			testServer.Close()
 		})
 	}
@@ -1,6 +1,15 @@
 package smartlogic
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const (
+	skosPrefix   = "http://www.w3.org/2004/02/skos/core#"
+	skosxlPrefix = "http://www.w3.org/2008/05/skos-xl#"
+)
 
 const (
 	// Concept types defined and available in the FT Ontology, required when creating new concept.
@@ -14,7 +23,7 @@ const (
 	// Concept schemas defined and available in the FT Production Model, required when creating new concept.
 	// Please not that concepts schemas are not the same as topic type and they are defined per Model.
 	// If you are about to create a concept under no schema, please be aware that this concept won't be visible in the
-	// Smartlogic UI.
+	// Smartlogic UI. The schemes of other models can be discovered with Client.ListConceptSchemes.
 	ConceptSchemaTopic        = "http://www.ft.com/ontology/scheme/Topics"
 	ConceptSchemaPerson       = "http://www.ft.com/thing/ConceptScheme/8e564c83-669c-48d5-a208-81fb88a32802"
 	ConceptSchemaOrganisation = "http://www.ft.com/ontology/scheme/Organisations"
@@ -24,15 +33,30 @@ const (
 	ConceptSchemaAuthor       = "http://www.ft.com/ontology/scheme/Authors"
 )
 
+// Label is a label or description in the given language.
+type Label struct {
+	Value    string
+	Language string
+}
+
 type Concept struct {
 	ID          string
 	PrefLabel   string
 	AltLabels   []string
 	Description string
 
+	// Labels and descriptions in languages other than English,
+	// the English ones are kept in PrefLabel, AltLabels and Description.
+	LocalizedPrefLabels   []Label
+	LocalizedAltLabels    []Label
+	LocalizedDescriptions []Label
+
 	Type         string
 	SchemaObject string
 	Broader      string
+	// Relations holds the IRIs of the related concepts by the relation property,
+	// e.g. skos:related or http://www.ft.com/ontology/hasParentOrganisation.
+	Relations map[string][]string
 
 	TMEIdentifier      string
 	FactsetIdentifier  string
@@ -43,19 +67,40 @@ type Concept struct {
 }
 
 func (c Concept) MarshalJSON() ([]byte, error) {
+	if err := c.validateLabels(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(c.toInput().object(defaultNamespace))
+}
+
+// hasPrefLabel reports whether the concept has a prefLabel in any language.
+func (c Concept) hasPrefLabel() bool {
+	return c.PrefLabel != "" || len(c.LocalizedPrefLabels) > 0
+}
+
+// validateLabels checks that the localized labels are tagged with a language other than English,
+// as the English ones are decoded into PrefLabel, AltLabels and Description.
+func (c Concept) validateLabels() error {
+	for _, labels := range [][]Label{c.LocalizedPrefLabels, c.LocalizedAltLabels, c.LocalizedDescriptions} {
+		for _, l := range labels {
+			if l.Language == "" || l.Language == "en" {
+				return fmt.Errorf("localized label %q should have language other than English defined", l.Value)
+			}
+		}
+	}
+	return nil
+}
+
+// toInput converts the concept to the input format of the Smartlogic API.
+func (c Concept) toInput() inputConcept {
 	input := inputConcept{
-		PrefLabel: []conceptLabel{{
-			LiteralForm: []wordValue{
-				{
-					Value:    c.PrefLabel,
-					Language: "en",
-				},
-			},
-			Type: []string{"skosxl:Label"},
-		}},
 		Type: []string{"skos:Concept", c.Type},
 	}
 
+	if c.PrefLabel != "" {
+		input.PrefLabel = []conceptLabel{newConceptLabel(Label{Value: c.PrefLabel, Language: "en"})}
+	}
+
 	if c.ID != "" {
 		input.ID = c.ID
 	}
@@ -72,6 +117,20 @@ func (c Concept) MarshalJSON() ([]byte, error) {
 		}
 	}
 
+	// Every localized label is a separate skosxl:Label with its own language.
+	for _, l := range c.LocalizedPrefLabels {
+		input.PrefLabel = append(input.PrefLabel, newConceptLabel(l))
+	}
+
+	for relation, ids := range c.Relations {
+		for _, id := range ids {
+			if input.Relations == nil {
+				input.Relations = map[string][]conceptID{}
+			}
+			input.Relations[relation] = append(input.Relations[relation], conceptID{ID: id})
+		}
+	}
+
 	if c.Description != "" {
 		input.Description = []wordValue{
 			{
@@ -80,6 +139,12 @@ func (c Concept) MarshalJSON() ([]byte, error) {
 			},
 		}
 	}
+	for _, l := range c.LocalizedDescriptions {
+		input.Description = append(input.Description, wordValue{
+			Value:    l.Value,
+			Language: l.Language,
+		})
+	}
 	for _, al := range c.AltLabels {
 		input.AltLabels = append(input.AltLabels, conceptLabel{
 
@@ -92,6 +157,9 @@ func (c Concept) MarshalJSON() ([]byte, error) {
 			Type: []string{"skosxl:Label"},
 		})
 	}
+	for _, l := range c.LocalizedAltLabels {
+		input.AltLabels = append(input.AltLabels, newConceptLabel(l))
+	}
 	if c.TMEIdentifier != "" {
 		input.TMEIdentifier = []conceptValue{
 			{
@@ -125,26 +193,232 @@ func (c Concept) MarshalJSON() ([]byte, error) {
 	if c.IsDeprecated {
 		input.IsDeprecated = []bool{c.IsDeprecated}
 	}
-	return json.Marshal(input)
+	return input
+}
+
+// ConceptSummary is the short form of a concept returned by the concept searches.
+type ConceptSummary struct {
+	ID          string
+	Type        string
+	DisplayName string
+
+	// FieldValues holds the values of the custom metadata field the search was matching on.
+	FieldValues []string
+}
+
+func decodeConceptSummary(data []byte, field string) (ConceptSummary, error) {
+	var node jsonldNode
+	if err := json.Unmarshal(data, &node); err != nil {
+		return ConceptSummary{}, err
+	}
+
+	summary := ConceptSummary{}
+	if err := node.decode("@id", &summary.ID); err != nil {
+		return ConceptSummary{}, err
+	}
+
+	types, err := node.types()
+	if err != nil {
+		return ConceptSummary{}, err
+	}
+	summary.Type = conceptType(types)
+
+	var displayNames wordValues
+	if err = node.decode("meta:displayName", &displayNames); err != nil {
+		return ConceptSummary{}, err
+	}
+	if values := englishValues(displayNames); len(values) > 0 {
+		summary.DisplayName = values[0]
+	}
+
+	var fieldValues wordValues
+	if err = node.decode(field, &fieldValues); err != nil {
+		return ConceptSummary{}, err
+	}
+	for _, v := range fieldValues {
+		summary.FieldValues = append(summary.FieldValues, v.Value)
+	}
+
+	return summary, nil
+}
+
+// UnmarshalJSON decodes a concept in the JSON-LD format returned by the Smartlogic API. It is the inverse of
+// MarshalJSON and accepts the properties both in their prefixed (skosxl:prefLabel) and in their fully expanded
+// (http://www.w3.org/2008/05/skos-xl#prefLabel) form. The English (or untagged) literal forms of the labels and
+// descriptions are decoded into PrefLabel, AltLabels and Description, the ones in other languages into the
+// Localized fields.
+func (c *Concept) UnmarshalJSON(data []byte) error {
+	return c.unmarshalJSON(data, defaultNamespace)
 }
 
-// inputConcept is helper struct matching the required input format for creating new concept in the Smartlogic API
+// unmarshalJSON decodes a concept with the properties from the given namespace.
+func (c *Concept) unmarshalJSON(data []byte, ns Namespace) error {
+	var node jsonldNode
+	if err := json.Unmarshal(data, &node); err != nil {
+		return err
+	}
+
+	concept := Concept{}
+	if err := node.decode("@id", &concept.ID); err != nil {
+		return err
+	}
+
+	types, err := node.types()
+	if err != nil {
+		return err
+	}
+	concept.Type = conceptType(types)
+
+	var ids conceptIDs
+	if err := node.decode("skos:topConceptOf", &ids, skosPrefix+"topConceptOf"); err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		concept.SchemaObject = ids[0].ID
+	}
+	ids = nil
+	if err := node.decode("skos:broader", &ids, skosPrefix+"broader"); err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		concept.Broader = ids[0].ID
+	}
+	if concept.Relations, err = node.relations(); err != nil {
+		return err
+	}
+
+	prefLabels, err := node.labels("skosxl:prefLabel", skosxlPrefix+"prefLabel")
+	if err != nil {
+		return err
+	}
+	if len(prefLabels) > 0 {
+		concept.PrefLabel = prefLabels[0]
+	}
+	concept.AltLabels, err = node.labels("skosxl:altLabel", skosxlPrefix+"altLabel")
+	if err != nil {
+		return err
+	}
+	concept.LocalizedPrefLabels, err = node.localizedLabels("skosxl:prefLabel", skosxlPrefix+"prefLabel")
+	if err != nil {
+		return err
+	}
+	concept.LocalizedAltLabels, err = node.localizedLabels("skosxl:altLabel", skosxlPrefix+"altLabel")
+	if err != nil {
+		return err
+	}
+
+	var description wordValues
+	if err = node.decode(ns.DescriptionProperty, &description); err != nil {
+		return err
+	}
+	if values := englishValues(description); len(values) > 0 {
+		concept.Description = values[0]
+	}
+	concept.LocalizedDescriptions = localizedValues(description)
+
+	identifiers := []struct {
+		key   string
+		field *string
+	}{
+		{ns.TMEIdentifierProperty, &concept.TMEIdentifier},
+		{ns.FactsetIdentifierProperty, &concept.FactsetIdentifier},
+		{ns.WikidataIdentifierProperty, &concept.WikidataIdentifier},
+		{ns.IndustryIdentifierProperty, &concept.IndustryIdentifier},
+	}
+	for _, identifier := range identifiers {
+		var values wordValues
+		if err = node.decode(identifier.key, &values); err != nil {
+			return err
+		}
+		if len(values) > 0 {
+			*identifier.field = values[0].Value
+		}
+	}
+
+	var deprecated wordValues
+	if err = node.decode(ns.IsDeprecatedProperty, &deprecated); err != nil {
+		return err
+	}
+	for _, d := range deprecated {
+		if d.Value == "true" {
+			concept.IsDeprecated = true
+		}
+	}
+
+	*c = concept
+	return nil
+}
+
+// inputConcept is helper struct matching the required input format for creating new concept in the Smartlogic API.
+// The IRIs of its properties depend on the namespace, so it is encoded through object.
 type inputConcept struct {
-	ID          string         `json:"@id,omitempty"`
-	PrefLabel   []conceptLabel `json:"skosxl:prefLabel,omitempty"`
-	AltLabels   []conceptLabel `json:"skosxl:altLabel,omitempty"`
-	Description []wordValue    `json:"http://www.ft.com/ontology/description,omitempty"`
+	ID          string
+	PrefLabel   []conceptLabel
+	AltLabels   []conceptLabel
+	Description []wordValue
 
-	Type         []string   `json:"@type,omitempty"`
-	TopConceptOf *conceptID `json:"skos:topConceptOf,omitempty"`
-	Broader      *conceptID `json:"skos:broader,omitempty"`
+	Type         []string
+	TopConceptOf *conceptID
+	Broader      *conceptID
+	Relations    map[string][]conceptID
 
-	TMEIdentifier      []conceptValue `json:"http://www.ft.com/ontology/TMEIdentifier,omitempty"`
-	FactsetIdentifier  []conceptValue `json:"http://www.ft.com/ontology/factsetIdentifier,omitempty"`
-	WikidataIdentifier []uriValue     `json:"http://www.ft.com/ontology/wikidataIdentifier,omitempty"`
-	IndustryIdentifier []conceptValue `json:"http://www.ft.com/ontology/industryIdentifier,omitempty"`
+	TMEIdentifier      []conceptValue
+	FactsetIdentifier  []conceptValue
+	WikidataIdentifier []uriValue
+	IndustryIdentifier []conceptValue
 
-	IsDeprecated []bool `json:"http://www.ft.com/ontology/isDeprecated,omitempty"`
+	IsDeprecated []bool
+}
+
+// object returns the JSON-LD object of the concept with the properties from the given namespace.
+// Empty properties are omitted.
+func (i inputConcept) object(ns Namespace) orderedObject {
+	obj := orderedObject{}
+	add := func(key string, value interface{}, empty bool) {
+		if !empty {
+			obj = append(obj, objectField{key, value})
+		}
+	}
+
+	add("@id", i.ID, i.ID == "")
+	add("skosxl:prefLabel", i.PrefLabel, len(i.PrefLabel) == 0)
+	add("skosxl:altLabel", i.AltLabels, len(i.AltLabels) == 0)
+	add(ns.DescriptionProperty, i.Description, len(i.Description) == 0)
+
+	add("@type", i.Type, len(i.Type) == 0)
+	add("skos:topConceptOf", i.TopConceptOf, i.TopConceptOf == nil)
+	add("skos:broader", i.Broader, i.Broader == nil)
+	relations := make([]string, 0, len(i.Relations))
+	for relation := range i.Relations {
+		relations = append(relations, relation)
+	}
+	sort.Strings(relations)
+	for _, relation := range relations {
+		add(relation, i.Relations[relation], len(i.Relations[relation]) == 0)
+	}
+
+	add(ns.TMEIdentifierProperty, i.TMEIdentifier, len(i.TMEIdentifier) == 0)
+	add(ns.FactsetIdentifierProperty, i.FactsetIdentifier, len(i.FactsetIdentifier) == 0)
+	add(ns.WikidataIdentifierProperty, i.WikidataIdentifier, len(i.WikidataIdentifier) == 0)
+	add(ns.IndustryIdentifierProperty, i.IndustryIdentifier, len(i.IndustryIdentifier) == 0)
+
+	add(ns.IsDeprecatedProperty, i.IsDeprecated, len(i.IsDeprecated) == 0)
+	return obj
+}
+
+// relationInput returns the input with the single relation to the concept with the given IRI.
+func relationInput(relation, relatedURI string) inputConcept {
+	return inputConcept{
+		Relations: map[string][]conceptID{relation: {{ID: relatedURI}}},
+	}
+}
+
+// conceptPatch is helper struct matching the input format for changing an existing concept in the Smartlogic API.
+// The values in Delete are removed from the concept and the values in Insert are added to it.
+type conceptPatch struct {
+	ID     string      `json:"@id"`
+	Delete interface{} `json:"sem:delete,omitempty"`
+	Insert interface{} `json:"sem:insert,omitempty"`
 }
 
 type conceptValue struct {
@@ -156,6 +430,45 @@ type wordValue struct {
 	Language string `json:"@language,omitempty"`
 }
 
+// UnmarshalJSON accepts both value objects and plain JSON-LD literals (strings, booleans and numbers).
+func (w *wordValue) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var value struct {
+			Value    json.RawMessage `json:"@value"`
+			Language string          `json:"@language"`
+		}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		w.Language = value.Language
+		data = value.Value
+	}
+
+	value, err := literalString(data)
+	if err != nil {
+		return err
+	}
+	w.Value = value
+	return nil
+}
+
+// literalString returns the string form of a JSON-LD literal given as JSON string, boolean or number.
+func literalString(data []byte) (string, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return s, nil
+	}
+	var literal interface{}
+	if err := json.Unmarshal(data, &literal); err != nil {
+		return "", err
+	}
+	switch literal.(type) {
+	case bool, float64:
+		return fmt.Sprint(literal), nil
+	}
+	return "", fmt.Errorf("unsupported literal value %s", string(data))
+}
+
 type uriValue struct {
 	Value string `json:"@value"`
 	Type  string `json:"@type"`
@@ -166,6 +479,307 @@ type conceptID struct {
 }
 
 type conceptLabel struct {
+	// ID refers to an existing label resource, e.g. when deleting it.
+	ID          string      `json:"@id,omitempty"`
 	LiteralForm []wordValue `json:"skosxl:literalForm,omitempty"`
 	Type        []string    `json:"@type,omitempty"`
 }
+
+func newConceptLabel(l Label) conceptLabel {
+	return conceptLabel{
+		LiteralForm: []wordValue{
+			{
+				Value:    l.Value,
+				Language: l.Language,
+			},
+		},
+		Type: []string{"skosxl:Label"},
+	}
+}
+
+// conceptIDs accepts node references given either as a single object or as an array of objects.
+type conceptIDs []conceptID
+
+func (ids *conceptIDs) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var id conceptID
+		if err := json.Unmarshal(data, &id); err != nil {
+			return err
+		}
+		*ids = conceptIDs{id}
+		return nil
+	}
+
+	var list []conceptID
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*ids = list
+	return nil
+}
+
+// jsonldNode is a single JSON-LD node with its properties kept undecoded,
+// so that they can be looked up by either their prefixed or expanded form.
+type jsonldNode map[string]json.RawMessage
+
+// jsonldNodes accepts nodes given either as a single object or as an array of objects.
+type jsonldNodes []jsonldNode
+
+func (nodes *jsonldNodes) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var node jsonldNode
+		if err := json.Unmarshal(data, &node); err != nil {
+			return err
+		}
+		*nodes = jsonldNodes{node}
+		return nil
+	}
+
+	var list []jsonldNode
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*nodes = list
+	return nil
+}
+
+// decode unmarshals the first of the given keys present in the node into v. Missing keys leave v untouched.
+func (n jsonldNode) decode(key string, v interface{}, aliases ...string) error {
+	for _, k := range append([]string{key}, aliases...) {
+		raw, ok := n[k]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, v); err != nil {
+			return fmt.Errorf("failed decoding %s: %w", k, err)
+		}
+		return nil
+	}
+	return nil
+}
+
+// types returns the values of @type, which can be either a single IRI or an array of IRIs.
+func (n jsonldNode) types() ([]string, error) {
+	var values wordValues
+	if err := n.decode("@type", &values); err != nil {
+		return nil, err
+	}
+	types := make([]string, 0, len(values))
+	for _, v := range values {
+		types = append(types, v.Value)
+	}
+	return types, nil
+}
+
+// conceptType returns the first of the given types which is not skos:Concept.
+func conceptType(types []string) string {
+	for _, t := range types {
+		if t != "skos:Concept" && t != skosPrefix+"Concept" {
+			return t
+		}
+	}
+	return ""
+}
+
+// nonRelationProperties are the hierarchy and label properties, which are not kept in Concept.Relations.
+var nonRelationProperties = map[string]bool{
+	"skos:broader":               true,
+	skosPrefix + "broader":       true,
+	"skos:narrower":              true,
+	skosPrefix + "narrower":      true,
+	"skos:topConceptOf":          true,
+	skosPrefix + "topConceptOf":  true,
+	"skos:hasTopConcept":         true,
+	skosPrefix + "hasTopConcept": true,
+	"skos:inScheme":              true,
+	skosPrefix + "inScheme":      true,
+	"skosxl:prefLabel":           true,
+	skosxlPrefix + "prefLabel":   true,
+	"skosxl:altLabel":            true,
+	skosxlPrefix + "altLabel":    true,
+	"skosxl:hiddenLabel":         true,
+	skosxlPrefix + "hiddenLabel": true,
+}
+
+// relations returns the properties of the node whose values are only references to other nodes,
+// except the hierarchy and label properties. It returns nil when there are none.
+func (n jsonldNode) relations() (map[string][]string, error) {
+	var relations map[string][]string
+	for key, raw := range n {
+		if nonRelationProperties[key] || len(key) == 0 || key[0] == '@' {
+			continue
+		}
+		var refs jsonldNodes
+		if err := json.Unmarshal(raw, &refs); err != nil {
+			// Not a list of objects, e.g. a literal value, so it cannot be a relation.
+			continue
+		}
+
+		ids := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			var id string
+			if len(ref) != 1 {
+				break
+			}
+			if err := ref.decode("@id", &id); err != nil {
+				return nil, err
+			}
+			if id == "" {
+				break
+			}
+			ids = append(ids, id)
+		}
+		if len(ids) == 0 || len(ids) != len(refs) {
+			continue
+		}
+		if relations == nil {
+			relations = map[string][]string{}
+		}
+		relations[key] = ids
+	}
+	return relations, nil
+}
+
+// isConcept reports whether skos:Concept is one of the given types.
+func isConcept(types []string) bool {
+	for _, t := range types {
+		if t == "skos:Concept" || t == skosPrefix+"Concept" {
+			return true
+		}
+	}
+	return false
+}
+
+// labels returns the English literal forms of the skosxl:Label nodes stored under the given keys.
+func (n jsonldNode) labels(key string, aliases ...string) ([]string, error) {
+	var labelNodes jsonldNodes
+	if err := n.decode(key, &labelNodes, aliases...); err != nil {
+		return nil, err
+	}
+
+	var labels []string
+	for _, labelNode := range labelNodes {
+		var literalForms wordValues
+		if err := labelNode.decode("skosxl:literalForm", &literalForms, skosxlPrefix+"literalForm"); err != nil {
+			return nil, err
+		}
+		if values := englishValues(literalForms); len(values) > 0 {
+			labels = append(labels, values[0])
+		}
+	}
+	return labels, nil
+}
+
+// labelRefs returns references to the skosxl:Label resources stored under the given keys.
+// It returns false if any of the labels has no @id, so that it cannot be referred to.
+func (n jsonldNode) labelRefs(key string, aliases ...string) ([]conceptLabel, bool, error) {
+	var labelNodes jsonldNodes
+	if err := n.decode(key, &labelNodes, aliases...); err != nil {
+		return nil, false, err
+	}
+
+	refs := make([]conceptLabel, 0, len(labelNodes))
+	for _, labelNode := range labelNodes {
+		var id string
+		if err := labelNode.decode("@id", &id); err != nil {
+			return nil, false, err
+		}
+		if id == "" {
+			return nil, false, nil
+		}
+		refs = append(refs, conceptLabel{ID: id})
+	}
+	return refs, true, nil
+}
+
+// localizedLabels returns the literal forms in languages other than English of the skosxl:Label nodes
+// stored under the given keys.
+func (n jsonldNode) localizedLabels(key string, aliases ...string) ([]Label, error) {
+	var labelNodes jsonldNodes
+	if err := n.decode(key, &labelNodes, aliases...); err != nil {
+		return nil, err
+	}
+
+	var labels []Label
+	for _, labelNode := range labelNodes {
+		var literalForms wordValues
+		if err := labelNode.decode("skosxl:literalForm", &literalForms, skosxlPrefix+"literalForm"); err != nil {
+			return nil, err
+		}
+		labels = append(labels, localizedValues(literalForms)...)
+	}
+	return labels, nil
+}
+
+// localizedValues returns the values tagged with a language other than English.
+func localizedValues(values []wordValue) []Label {
+	var labels []Label
+	for _, v := range values {
+		if v.Language != "" && v.Language != "en" {
+			labels = append(labels, Label{Value: v.Value, Language: v.Language})
+		}
+	}
+	return labels
+}
+
+// englishValues returns the values tagged as English. Untagged values are used only when there is no English one.
+func englishValues(values []wordValue) []string {
+	var english, untagged []string
+	for _, v := range values {
+		switch v.Language {
+		case "en":
+			english = append(english, v.Value)
+		case "":
+			untagged = append(untagged, v.Value)
+		}
+	}
+	if len(english) > 0 {
+		return english
+	}
+	return untagged
+}
+
+// wordValues accepts literals given either as a single value or as an array of values.
+type wordValues []wordValue
+
+func (values *wordValues) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] != '[' {
+		var value wordValue
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*values = wordValues{value}
+		return nil
+	}
+
+	var list []wordValue
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*values = list
+	return nil
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalLabels(a, b []Label) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -3,6 +3,7 @@ package smartlogic
 import (
 	"bytes"
 	"encoding/json"
+	"reflect"
 	"testing"
 )
 
@@ -159,6 +160,41 @@ func TestConceptMarshalJSON(t *testing.T) {
 			*/
 			expectedError: false,
 		},
+		{
+			name: "multilingual concept",
+			concept: Concept{
+				PrefLabel:             "Germany",
+				AltLabels:             []string{"Federal Republic of Germany"},
+				Description:           "Country in Europe",
+				LocalizedPrefLabels:   []Label{{Value: "Deutschland", Language: "de"}},
+				LocalizedAltLabels:    []Label{{Value: "BRD", Language: "de"}},
+				LocalizedDescriptions: []Label{{Value: "Land in Europa", Language: "de"}},
+				Type:                  TypeLocation,
+				SchemaObject:          ConceptSchemaLocation,
+			},
+			expectedJSON:  `{"skosxl:prefLabel":[{"skosxl:literalForm":[{"@value":"Germany","@language":"en"}],"@type":["skosxl:Label"]},{"skosxl:literalForm":[{"@value":"Deutschland","@language":"de"}],"@type":["skosxl:Label"]}],"skosxl:altLabel":[{"skosxl:literalForm":[{"@value":"Federal Republic of Germany","@language":"en"}],"@type":["skosxl:Label"]},{"skosxl:literalForm":[{"@value":"BRD","@language":"de"}],"@type":["skosxl:Label"]}],"http://www.ft.com/ontology/description":[{"@value":"Country in Europe","@language":"en"},{"@value":"Land in Europa","@language":"de"}],"@type":["skos:Concept","http://www.ft.com/ontology/Location"],"skos:topConceptOf":{"@id":"http://www.ft.com/thing/ConceptScheme/ae342e72-e8a3-41e4-aaf4-180506750948"}}`,
+			expectedError: false,
+		},
+		{
+			name: "concept without English labels",
+			concept: Concept{
+				LocalizedPrefLabels: []Label{{Value: "Deutschland", Language: "de"}},
+				Type:                TypeLocation,
+				SchemaObject:        ConceptSchemaLocation,
+			},
+			expectedJSON:  `{"skosxl:prefLabel":[{"skosxl:literalForm":[{"@value":"Deutschland","@language":"de"}],"@type":["skosxl:Label"]}],"@type":["skos:Concept","http://www.ft.com/ontology/Location"],"skos:topConceptOf":{"@id":"http://www.ft.com/thing/ConceptScheme/ae342e72-e8a3-41e4-aaf4-180506750948"}}`,
+			expectedError: false,
+		},
+		{
+			name: "English localized label",
+			concept: Concept{
+				PrefLabel:          "Germany",
+				LocalizedAltLabels: []Label{{Value: "FRG", Language: "en"}},
+				Type:               TypeLocation,
+				SchemaObject:       ConceptSchemaLocation,
+			},
+			expectedError: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -176,3 +212,154 @@ func TestConceptMarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestConceptUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name            string
+		json            string
+		expectedConcept Concept
+		expectedError   bool
+	}{
+		{
+			name: "minimal concept",
+			json: `{"skosxl:prefLabel":[{"skosxl:literalForm":[{"@value":"Test Person","@language":"en"}],"@type":["skosxl:Label"]}],"@type":["skos:Concept","http://www.ft.com/ontology/person/Person"],"skos:topConceptOf":{"@id":"http://www.ft.com/thing/ConceptScheme/8e564c83-669c-48d5-a208-81fb88a32802"}}`,
+			expectedConcept: Concept{
+				PrefLabel:    "Test Person",
+				Type:         TypePerson,
+				SchemaObject: ConceptSchemaPerson,
+			},
+		},
+		{
+			name: "concept returned by Smartlogic",
+			json: `{"@id":"http://www.ft.com/thing/1e5fd0a8-7a8c-4b9c-8e0e-2d1c2b3b7f4c","@type":["skos:Concept","http://www.ft.com/ontology/Topic"],"skosxl:prefLabel":[{"skosxl:literalForm":[{"@value":"Climate","@language":"en"}]}],"skos:broader":[{"@id":"http://www.ft.com/thing/b3b1e6a2-6a5c-4a3e-9f0f-2f3c8f6b5c1d"}],"http://www.ft.com/ontology/TMEIdentifier":[{"@value":"Q2xpbWF0ZQ==-VG9waWNz"}],"http://www.ft.com/ontology/isDeprecated":[true]}`,
+			expectedConcept: Concept{
+				ID:            "http://www.ft.com/thing/1e5fd0a8-7a8c-4b9c-8e0e-2d1c2b3b7f4c",
+				PrefLabel:     "Climate",
+				Type:          TypeTopic,
+				Broader:       "http://www.ft.com/thing/b3b1e6a2-6a5c-4a3e-9f0f-2f3c8f6b5c1d",
+				TMEIdentifier: "Q2xpbWF0ZQ==-VG9waWNz",
+				IsDeprecated:  true,
+			},
+		},
+		{
+			name: "expanded form with multiple languages",
+			json: `{"@id":"http://www.ft.com/thing/1e5fd0a8-7a8c-4b9c-8e0e-2d1c2b3b7f4c","@type":["http://www.w3.org/2004/02/skos/core#Concept","http://www.ft.com/ontology/Location"],"http://www.w3.org/2008/05/skos-xl#prefLabel":[{"http://www.w3.org/2008/05/skos-xl#literalForm":[{"@value":"Deutschland","@language":"de"},{"@value":"Germany","@language":"en"}]}],"http://www.w3.org/2008/05/skos-xl#altLabel":[{"http://www.w3.org/2008/05/skos-xl#literalForm":[{"@value":"BRD","@language":"de"}]},{"http://www.w3.org/2008/05/skos-xl#literalForm":[{"@value":"Federal Republic of Germany","@language":"en"}]}],"http://www.w3.org/2004/02/skos/core#topConceptOf":[{"@id":"http://www.ft.com/thing/ConceptScheme/ae342e72-e8a3-41e4-aaf4-180506750948"}],"http://www.ft.com/ontology/description":[{"@value":"Land in Europa","@language":"de"},{"@value":"Country in Europe","@language":"en"}],"http://www.ft.com/ontology/isDeprecated":[{"@value":"false","@type":"xsd:boolean"}]}`,
+			expectedConcept: Concept{
+				ID:                    "http://www.ft.com/thing/1e5fd0a8-7a8c-4b9c-8e0e-2d1c2b3b7f4c",
+				PrefLabel:             "Germany",
+				AltLabels:             []string{"Federal Republic of Germany"},
+				Description:           "Country in Europe",
+				LocalizedPrefLabels:   []Label{{Value: "Deutschland", Language: "de"}},
+				LocalizedAltLabels:    []Label{{Value: "BRD", Language: "de"}},
+				LocalizedDescriptions: []Label{{Value: "Land in Europa", Language: "de"}},
+				Type:                  TypeLocation,
+				SchemaObject:          ConceptSchemaLocation,
+			},
+		},
+		{
+			name: "single values instead of arrays",
+			json: `{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","@type":"http://www.ft.com/ontology/organisation/Organisation","skosxl:prefLabel":{"skosxl:literalForm":{"@value":"Apple Inc","@language":"en"}},"skosxl:altLabel":{"skosxl:literalForm":"Apple"},"http://www.ft.com/ontology/description":{"@value":"Technology company","@language":"en"},"http://www.ft.com/ontology/factsetIdentifier":{"@value":"000C7F-E"},"http://www.ft.com/ontology/isDeprecated":true}`,
+			expectedConcept: Concept{
+				ID:                "http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0",
+				PrefLabel:         "Apple Inc",
+				AltLabels:         []string{"Apple"},
+				Description:       "Technology company",
+				Type:              TypeOrganisation,
+				FactsetIdentifier: "000C7F-E",
+				IsDeprecated:      true,
+			},
+		},
+		{
+			name: "relations and hidden labels",
+			json: `{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","@type":["skos:Concept","http://www.ft.com/ontology/organisation/Organisation"],"skosxl:prefLabel":[{"skosxl:literalForm":[{"@value":"Apple Inc","@language":"en"}]}],"skosxl:hiddenLabel":[{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0_hiddenLabel"}],"http://www.w3.org/2008/05/skos-xl#hiddenLabel":[{"@id":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0_hiddenLabel2"}],"skos:related":[{"@id":"http://www.ft.com/thing/0a619d71-9af5-3755-90dd-f789b686c67a"}]}`,
+			expectedConcept: Concept{
+				ID:        "http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0",
+				PrefLabel: "Apple Inc",
+				Type:      TypeOrganisation,
+				Relations: map[string][]string{
+					"skos:related": {"http://www.ft.com/thing/0a619d71-9af5-3755-90dd-f789b686c67a"},
+				},
+			},
+		},
+		{
+			name:          "invalid json",
+			json:          `["skos:Concept"]`,
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var concept Concept
+			err := json.Unmarshal([]byte(test.json), &concept)
+			if err != nil && !test.expectedError {
+				t.Errorf("unexpected error unmarshalling concept: %v", err)
+			}
+			if err == nil && test.expectedError {
+				t.Errorf("expected error unmarshalling concept")
+			}
+			if !test.expectedError && !reflect.DeepEqual(concept, test.expectedConcept) {
+				t.Errorf("unexpected concept returned, got %+v, want %+v", concept, test.expectedConcept)
+			}
+		})
+	}
+}
+
+func TestConceptMarshalUnmarshalRoundTrip(t *testing.T) {
+	concepts := []Concept{
+		{
+			LocalizedPrefLabels: []Label{{Value: "Deutschland", Language: "de"}},
+			Type:                TypeLocation,
+			SchemaObject:        ConceptSchemaLocation,
+		},
+		{
+			PrefLabel:    "Test Person",
+			Type:         TypePerson,
+			SchemaObject: ConceptSchemaPerson,
+		},
+		{
+			ID:          "http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0",
+			PrefLabel:   "Test Organisation All Fields",
+			AltLabels:   []string{"Short Name", "Other Name"},
+			Description: "New test organisation",
+			LocalizedPrefLabels: []Label{
+				{Value: "Organisation de test", Language: "fr"},
+				{Value: "Testorganisation", Language: "de"},
+			},
+			LocalizedAltLabels:    []Label{{Value: "Nom court", Language: "fr"}},
+			LocalizedDescriptions: []Label{{Value: "Nouvelle organisation de test", Language: "fr"}},
+			Type:                  TypeOrganisation,
+			SchemaObject:          ConceptSchemaOrganisation,
+			Broader:               "http://www.ft.com/thing/b3b1e6a2-6a5c-4a3e-9f0f-2f3c8f6b5c1d",
+			Relations: map[string][]string{
+				"skos:related": {"http://www.ft.com/thing/0a619d71-9af5-3755-90dd-f789b686c67a"},
+				"http://www.ft.com/ontology/hasParentOrganisation": {
+					"http://www.ft.com/thing/2a6e3b6e-7a6c-3b14-9c2e-d6bc6e5c8f5d",
+					"http://www.ft.com/thing/5c3d2f1e-0b9a-3c8d-8e7f-6a5b4c3d2e1f",
+				},
+			},
+			TMEIdentifier:      "TnN0ZWluX09OX0ZvcnR1bmVDb21wYW55X0FBUEw=-T04=",
+			FactsetIdentifier:  "000C7F-E",
+			WikidataIdentifier: "http://www.wikidata.org/entity/Q312",
+			IndustryIdentifier: "Q312",
+			IsDeprecated:       true,
+		},
+	}
+
+	for _, concept := range concepts {
+		t.Run(concept.PrefLabel, func(t *testing.T) {
+			data, err := json.Marshal(concept)
+			if err != nil {
+				t.Fatalf("unexpected error marshalling concept: %v", err)
+			}
+			var decoded Concept
+			err = json.Unmarshal(data, &decoded)
+			if err != nil {
+				t.Fatalf("unexpected error unmarshalling concept: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, concept) {
+				t.Errorf("concept changed after round trip, got %+v, want %+v", decoded, concept)
+			}
+		})
+	}
+}
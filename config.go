@@ -0,0 +1,277 @@
+package smartlogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Environment variables read by ConfigFromEnv.
+const (
+	EnvBaseURL   = "SMARTLOGIC_BASE_URL"
+	EnvClientID  = "SMARTLOGIC_CLIENT_ID"
+	EnvAPIKey    = "SMARTLOGIC_API_KEY"
+	EnvModel     = "SMARTLOGIC_MODEL"
+	EnvTimeout   = "SMARTLOGIC_TIMEOUT"
+	EnvUserAgent = "SMARTLOGIC_USER_AGENT"
+
+	EnvConceptURIPrefix    = "SMARTLOGIC_CONCEPT_URI_PREFIX"
+	EnvMetadataFieldPrefix = "SMARTLOGIC_METADATA_FIELD_PREFIX"
+)
+
+// Config holds the Client configuration which can be given as plain values, e.g. from environment variables.
+type Config struct {
+	// BaseURL is the Smartlogic Cloud URL, e.g. https://cloud.smartlogic.com.
+	BaseURL  string
+	ClientID string
+	APIKey   string
+	Model    string
+
+	// Timeout limits the time of each http request made by the client. Zero means no timeout.
+	Timeout   time.Duration
+	UserAgent string
+
+	// ConceptURIPrefix and MetadataFieldPrefix override the prefixes of the client namespace when set,
+	// unless a WithNamespace option setting them is given after the Config.
+	ConceptURIPrefix    string
+	MetadataFieldPrefix string
+}
+
+// ConfigFromEnv populates Config from the SMARTLOGIC_* environment variables.
+// SMARTLOGIC_TIMEOUT is parsed as time.Duration, e.g. 30s.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		BaseURL:   os.Getenv(EnvBaseURL),
+		ClientID:  os.Getenv(EnvClientID),
+		APIKey:    os.Getenv(EnvAPIKey),
+		Model:     os.Getenv(EnvModel),
+		UserAgent: os.Getenv(EnvUserAgent),
+
+		ConceptURIPrefix:    os.Getenv(EnvConceptURIPrefix),
+		MetadataFieldPrefix: os.Getenv(EnvMetadataFieldPrefix),
+	}
+
+	if timeout := os.Getenv(EnvTimeout); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", EnvTimeout, err)
+		}
+		cfg.Timeout = d
+	}
+
+	return cfg, nil
+}
+
+// Option configures the Client created by New.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	config      Config
+	httpClient  *http.Client
+	tokenSource TokenSource
+	namespace   Namespace
+	retryPolicy RetryPolicy
+	rateLimits  *RateLimits
+	observer    RateLimitObserver
+	lazy        bool
+}
+
+// WithConfig sets all the values of the Config. Options given after it override its values.
+func WithConfig(cfg Config) Option {
+	return func(o *clientOptions) {
+		o.config = cfg
+	}
+}
+
+// WithBaseURL sets the Smartlogic Cloud URL.
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.config.BaseURL = baseURL
+	}
+}
+
+// WithClientID sets the Smartlogic Cloud client ID.
+func WithClientID(clientID string) Option {
+	return func(o *clientOptions) {
+		o.config.ClientID = clientID
+	}
+}
+
+// WithAPIKey sets the API key the access tokens are requested with. It is not needed when WithTokenSource is used.
+func WithAPIKey(apiKey string) Option {
+	return func(o *clientOptions) {
+		o.config.APIKey = apiKey
+	}
+}
+
+// WithModel sets the Smartlogic model the client works with.
+func WithModel(model string) Option {
+	return func(o *clientOptions) {
+		o.config.Model = model
+	}
+}
+
+// WithTimeout limits the time of each http request made by the client.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.config.Timeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests made by the client.
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.config.UserAgent = userAgent
+	}
+}
+
+// WithHTTPClient sets the http client used for the requests. By default a new http.Client is used.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTokenSource sets the source of the access tokens used instead of the API key.
+func WithTokenSource(tokenSource TokenSource) Option {
+	return func(o *clientOptions) {
+		o.tokenSource = tokenSource
+	}
+}
+
+// WithNamespace sets the IRIs of the concepts and their properties, FTNamespace is used by default.
+// The prefixes it sets override the ones of the Config given before it.
+func WithNamespace(ns Namespace) Option {
+	return func(o *clientOptions) {
+		o.namespace = ns
+		if ns.ConceptURIPrefix != "" {
+			o.config.ConceptURIPrefix = ""
+		}
+		if ns.MetadataFieldPrefix != "" {
+			o.config.MetadataFieldPrefix = ""
+		}
+	}
+}
+
+// WithRetryPolicy enables retrying of the requests failed for transient reasons, see RetryPolicy.
+// By default the requests are not retried.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithRateLimits limits the rate of the requests made by the client, shared by all goroutines using it.
+// By default the requests are not limited.
+func WithRateLimits(limits RateLimits) Option {
+	return func(o *clientOptions) {
+		o.rateLimits = &limits
+	}
+}
+
+// WithRateLimitObserver sets the function called with the time each request waited for the rate limiter.
+func WithRateLimitObserver(observer RateLimitObserver) Option {
+	return func(o *clientOptions) {
+		o.observer = observer
+	}
+}
+
+// WithLazyTokens defers getting the first access token until the client is first used,
+// so New makes no request to Smartlogic. Use Client.Ping to check the credentials and the availability of Smartlogic.
+func WithLazyTokens() Option {
+	return func(o *clientOptions) {
+		o.lazy = true
+	}
+}
+
+// New creates Client configured by the options. The base URL, client ID and model are required,
+// together with either the API key or the token source.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	o := clientOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	baseURL, err := o.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	if o.config.Timeout > 0 {
+		// Copy the http client, so the timeout does not change the one given by the caller.
+		c := *httpClient
+		c.Timeout = o.config.Timeout
+		httpClient = &c
+	}
+
+	tokenSource := o.tokenSource
+	if tokenSource == nil {
+		tokenSource = APIKeyTokenSource(httpClient, baseURL, o.config.APIKey)
+	}
+
+	client := newClient(httpClient, baseURL, o.config.ClientID, o.config.Model, tokenSource)
+	client.userAgent = o.config.UserAgent
+	client.namespace = o.clientNamespace()
+	client.retryPolicy = o.retryPolicy
+	if o.rateLimits != nil || o.observer != nil {
+		limits := RateLimits{}
+		if o.rateLimits != nil {
+			limits = *o.rateLimits
+		}
+		client.limiter = newRateLimiter(limits, o.observer)
+	}
+
+	if !o.lazy {
+		if _, err = client.tokens.Token(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+// clientNamespace returns the namespace set by the options with the prefixes from Config, which are only left
+// when they were set after the namespace or the namespace has none.
+func (o clientOptions) clientNamespace() Namespace {
+	ns := o.namespace
+	if o.config.ConceptURIPrefix != "" {
+		ns.ConceptURIPrefix = o.config.ConceptURIPrefix
+	}
+	if o.config.MetadataFieldPrefix != "" {
+		ns.MetadataFieldPrefix = o.config.MetadataFieldPrefix
+	}
+	return ns.withDefaults()
+}
+
+func (o clientOptions) validate() (*url.URL, error) {
+	if o.config.BaseURL == "" {
+		return nil, errors.New("smartlogic base URL is required")
+	}
+	baseURL, err := url.Parse(o.config.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smartlogic base URL: %w", err)
+	}
+	if (baseURL.Scheme != "http" && baseURL.Scheme != "https") || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid smartlogic base URL %q: absolute http or https URL is required", o.config.BaseURL)
+	}
+
+	if o.config.ClientID == "" {
+		return nil, errors.New("smartlogic client ID is required")
+	}
+	if o.config.Model == "" {
+		return nil, errors.New("smartlogic model is required")
+	}
+	if o.config.APIKey == "" && o.tokenSource == nil {
+		return nil, errors.New("either smartlogic API key or token source is required")
+	}
+
+	return baseURL, nil
+}
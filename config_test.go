@@ -0,0 +1,146 @@
+package smartlogic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			if req.Header.Get("User-Agent") != "test-agent" {
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		}))
+	defer testServer.Close()
+
+	tests := []struct {
+		name          string
+		opts          []Option
+		expectedError bool
+	}{
+		{
+			name: "success",
+			opts: []Option{
+				WithConfig(Config{BaseURL: testServer.URL, ClientID: "testClientID", APIKey: "testAPIKey", Model: "testModel"}),
+				WithUserAgent("test-agent"),
+				WithTimeout(time.Second),
+			},
+			expectedError: false,
+		},
+		{
+			name: "success with token source",
+			opts: []Option{
+				WithBaseURL(testServer.URL),
+				WithClientID("testClientID"),
+				WithModel("testModel"),
+				WithTokenSource(StaticTokenSource("test_token")),
+				WithUserAgent("test-agent"),
+			},
+			expectedError: false,
+		},
+		{
+			name: "relative base URL",
+			opts: []Option{
+				WithBaseURL("smartlogic/cloud"),
+				WithClientID("testClientID"),
+				WithAPIKey("testAPIKey"),
+				WithModel("testModel"),
+			},
+			expectedError: true,
+		},
+		{
+			name: "no client ID",
+			opts: []Option{
+				WithBaseURL(testServer.URL),
+				WithAPIKey("testAPIKey"),
+				WithModel("testModel"),
+			},
+			expectedError: true,
+		},
+		{
+			name: "no model",
+			opts: []Option{
+				WithBaseURL(testServer.URL),
+				WithClientID("testClientID"),
+				WithAPIKey("testAPIKey"),
+			},
+			expectedError: true,
+		},
+		{
+			name: "no credentials",
+			opts: []Option{
+				WithBaseURL(testServer.URL),
+				WithClientID("testClientID"),
+				WithModel("testModel"),
+			},
+			expectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.TODO()
+			opts := append([]Option{WithHTTPClient(testServer.Client())}, test.opts...)
+			client, err := New(ctx, opts...)
+			if err != nil && !test.expectedError {
+				t.Fatalf("unexpected error creating client: %v", err)
+			}
+			if err == nil && test.expectedError {
+				t.Fatalf("expected error creating client")
+			}
+			if client == nil {
+				return
+			}
+			err = client.Ping(ctx)
+			if err != nil {
+				t.Errorf("unexpected error pinging Smartlogic: %v", err)
+			}
+		})
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	env := map[string]string{
+		EnvBaseURL:   "https://cloud.smartlogic.com",
+		EnvClientID:  "testClientID",
+		EnvAPIKey:    "testAPIKey",
+		EnvModel:     "testModel",
+		EnvTimeout:   "30s",
+		EnvUserAgent: "test-agent",
+	}
+	for k, v := range env {
+		os.Setenv(k, v)
+		defer os.Unsetenv(k)
+	}
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error reading config: %v", err)
+	}
+	expected := Config{
+		BaseURL:   "https://cloud.smartlogic.com",
+		ClientID:  "testClientID",
+		APIKey:    "testAPIKey",
+		Model:     "testModel",
+		Timeout:   30 * time.Second,
+		UserAgent: "test-agent",
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("unexpected config, got %+v, want %+v", cfg, expected)
+	}
+
+	os.Setenv(EnvTimeout, "thirty seconds")
+	_, err = ConfigFromEnv()
+	if err == nil {
+		t.Errorf("expected error reading config with invalid timeout")
+	}
+}
@@ -0,0 +1,183 @@
+package smartlogic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize limits how much of an error response body is read into APIError.
+const maxErrorBodySize = 64 * 1024
+
+// Sentinel errors matched by APIError, so they can be checked with errors.Is.
+var (
+	ErrNotFound     = errors.New("smartlogic: not found")
+	ErrConflict     = errors.New("smartlogic: conflict")
+	ErrUnauthorized = errors.New("smartlogic: unauthorized")
+)
+
+// APIMessage is a single error or warning reported by Smartlogic.
+type APIMessage struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Subject is the IRI of the concept the message is about, if any.
+	Subject string `json:"subject"`
+}
+
+// APIError is returned when Smartlogic responds with an unexpected http status.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	RequestID  string
+
+	Errors   []APIMessage
+	Warnings []APIMessage
+	// Body is the raw response body, kept for the responses which are not in the Smartlogic error format.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("smartlogic: %s %s returned status %v", e.Method, e.URL, e.StatusCode)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request id %s)", e.RequestID)
+	}
+
+	var details []string
+	for _, m := range e.Errors {
+		details = append(details, m.Message)
+	}
+	for _, m := range e.Warnings {
+		details = append(details, "warning: "+m.Message)
+	}
+	if len(details) == 0 && e.Body != "" {
+		details = append(details, e.Body)
+	}
+	if len(details) > 0 {
+		msg += ": " + strings.Join(details, "; ")
+	}
+	return msg
+}
+
+// Is reports whether the error matches one of the sentinel errors based on its http status.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// newAPIError reads the response body and decodes the Smartlogic error payload from it. The body is not closed.
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.URL = resp.Request.URL.String()
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil || len(data) == 0 {
+		return apiErr
+	}
+
+	var payload struct {
+		Errors   []APIMessage `json:"errors"`
+		Warnings []APIMessage `json:"warnings"`
+	}
+	if err = json.Unmarshal(data, &payload); err == nil && (len(payload.Errors) > 0 || len(payload.Warnings) > 0) {
+		apiErr.Errors = payload.Errors
+		apiErr.Warnings = payload.Warnings
+		return apiErr
+	}
+
+	apiErr.Body = strings.TrimSpace(string(data))
+	return apiErr
+}
+
+// WarningsError is returned when Smartlogic rejects a write request because of warnings, e.g. a duplicate label.
+// If the warnings are harmless, the same request can be repeated with the AcceptWarnings option.
+type WarningsError struct {
+	Warnings []APIMessage
+	Err      *APIError
+}
+
+func (e *WarningsError) Error() string {
+	messages := make([]string, 0, len(e.Warnings))
+	for _, w := range e.Warnings {
+		if w.Subject != "" {
+			messages = append(messages, fmt.Sprintf("%s (%s)", w.Message, w.Subject))
+			continue
+		}
+		messages = append(messages, w.Message)
+	}
+	return fmt.Sprintf("smartlogic: request rejected because of warnings: %s", strings.Join(messages, "; "))
+}
+
+func (e *WarningsError) Unwrap() error {
+	return e.Err
+}
+
+// newWriteError returns WarningsError if the write request was rejected only because of warnings, otherwise APIError.
+func newWriteError(resp *http.Response) error {
+	apiErr := newAPIError(resp)
+	if len(apiErr.Warnings) > 0 && len(apiErr.Errors) == 0 {
+		return &WarningsError{
+			Warnings: apiErr.Warnings,
+			Err:      apiErr,
+		}
+	}
+	return apiErr
+}
+
+// ConceptRelationshipsError is returned when Smartlogic refuses to remove a concept
+// because of its existing relationships with other concepts.
+type ConceptRelationshipsError struct {
+	ConceptID string
+	Err       *APIError
+}
+
+func (e *ConceptRelationshipsError) Error() string {
+	return fmt.Sprintf("concept %s has existing relationships: %v", e.ConceptID, e.Err)
+}
+
+func (e *ConceptRelationshipsError) Unwrap() error {
+	return e.Err
+}
+
+// newDeleteConceptError returns ConceptRelationshipsError if the response is a refusal to delete the concept,
+// which Smartlogic responds with conflict only because of its relationships, otherwise the same errors as newWriteError.
+func newDeleteConceptError(conceptID string, resp *http.Response) error {
+	err := newWriteError(resp)
+	apiErr, ok := err.(*APIError)
+	if ok && resp.StatusCode == http.StatusConflict {
+		return &ConceptRelationshipsError{
+			ConceptID: conceptID,
+			Err:       apiErr,
+		}
+	}
+	return err
+}
+
+// HierarchyCycleError is returned when the broader or narrower relations walked from a concept form a cycle.
+type HierarchyCycleError struct {
+	ConceptID string
+	Relation  string
+	// Cycle holds the URIs of the concepts in the cycle, the first one is repeated at the end.
+	Cycle []string
+}
+
+func (e *HierarchyCycleError) Error() string {
+	return fmt.Sprintf("%s relations of concept %s form a cycle: %s", e.Relation, e.ConceptID, strings.Join(e.Cycle, " -> "))
+}
@@ -0,0 +1,90 @@
+package smartlogic
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedError *APIError
+		sentinel      error
+	}{
+		{
+			name:       "smartlogic error payload",
+			statusCode: http.StatusConflict,
+			body:       `{"warnings":[{"code":"DUPLICATE_LABEL","message":"Label Apple Inc is already used","subject":"http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0"}]}`,
+			expectedError: &APIError{
+				StatusCode: http.StatusConflict,
+				Method:     http.MethodPost,
+				URL:        "http://smartlogic/api",
+				RequestID:  "tid_test",
+				Warnings: []APIMessage{{
+					Code:    "DUPLICATE_LABEL",
+					Message: "Label Apple Inc is already used",
+					Subject: "http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0",
+				}},
+			},
+			sentinel: ErrConflict,
+		},
+		{
+			name:       "html body",
+			statusCode: http.StatusNotFound,
+			body:       "<html>Not Found</html>\n",
+			expectedError: &APIError{
+				StatusCode: http.StatusNotFound,
+				Method:     http.MethodPost,
+				URL:        "http://smartlogic/api",
+				RequestID:  "tid_test",
+				Body:       "<html>Not Found</html>",
+			},
+			sentinel: ErrNotFound,
+		},
+		{
+			name:       "empty body",
+			statusCode: http.StatusUnauthorized,
+			expectedError: &APIError{
+				StatusCode: http.StatusUnauthorized,
+				Method:     http.MethodPost,
+				URL:        "http://smartlogic/api",
+				RequestID:  "tid_test",
+			},
+			sentinel: ErrUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reqURL, _ := url.Parse("http://smartlogic/api")
+			resp := &http.Response{
+				StatusCode: test.statusCode,
+				Header:     http.Header{"X-Request-Id": []string{"tid_test"}},
+				Body:       ioutil.NopCloser(strings.NewReader(test.body)),
+				Request:    &http.Request{Method: http.MethodPost, URL: reqURL},
+			}
+
+			apiErr := newAPIError(resp)
+			if !reflect.DeepEqual(apiErr, test.expectedError) {
+				t.Errorf("unexpected api error, got %+v, want %+v", apiErr, test.expectedError)
+			}
+
+			err := fmt.Errorf("failed request: %w", apiErr)
+			if !errors.Is(err, test.sentinel) {
+				t.Errorf("expected error to match %v", test.sentinel)
+			}
+			var target *APIError
+			if !errors.As(err, &target) {
+				t.Errorf("expected error to be APIError")
+			}
+		})
+	}
+}
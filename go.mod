@@ -1,3 +1,5 @@
 module github.com/Financial-Times/smartlogic-sdk
 
 go 1.14
+
+require golang.org/x/oauth2 v0.5.0
@@ -0,0 +1,164 @@
+package smartlogic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Properties linking the concepts of the hierarchy.
+const (
+	broaderProperty  = "skos:broader"
+	narrowerProperty = "skos:narrower"
+)
+
+// HierarchyConcept is a concept found by walking the hierarchy from another concept.
+type HierarchyConcept struct {
+	Concept
+	// Depth is the shortest distance from the starting concept, 1 for its direct broader or narrower concepts.
+	Depth int
+}
+
+// GetBroaderConcepts returns the direct broader concepts of the concept with the given UUID.
+func (c *Client) GetBroaderConcepts(ctx context.Context, task, conceptID string) ([]HierarchyConcept, error) {
+	return c.walkHierarchy(ctx, task, conceptID, broaderProperty, 1)
+}
+
+// GetNarrowerConcepts returns the direct narrower concepts of the concept with the given UUID.
+func (c *Client) GetNarrowerConcepts(ctx context.Context, task, conceptID string) ([]HierarchyConcept, error) {
+	return c.walkHierarchy(ctx, task, conceptID, narrowerProperty, 1)
+}
+
+// GetAncestors returns all the transitive broader concepts of the concept with the given UUID,
+// ordered by their depth. HierarchyCycleError is returned if the broader relations form a cycle.
+func (c *Client) GetAncestors(ctx context.Context, task, conceptID string) ([]HierarchyConcept, error) {
+	return c.walkHierarchy(ctx, task, conceptID, broaderProperty, 0)
+}
+
+// GetDescendants returns all the transitive narrower concepts of the concept with the given UUID,
+// ordered by their depth. HierarchyCycleError is returned if the narrower relations form a cycle.
+func (c *Client) GetDescendants(ctx context.Context, task, conceptID string) ([]HierarchyConcept, error) {
+	return c.walkHierarchy(ctx, task, conceptID, narrowerProperty, 0)
+}
+
+// walkHierarchy follows the relation breadth first from the concept up to the given depth, zero meaning no limit.
+// Every concept is requested once, so the walk ends even when the relations form a cycle,
+// which is then reported after the walk.
+func (c *Client) walkHierarchy(ctx context.Context, task, conceptID, relation string, maxDepth int) ([]HierarchyConcept, error) {
+	startURI := c.namespace.conceptURI(conceptID)
+
+	var result []HierarchyConcept
+	edges := map[string][]string{}
+	visited := map[string]bool{startURI: true}
+	level := []string{startURI}
+	for depth := 1; len(level) > 0 && (maxDepth == 0 || depth <= maxDepth); depth++ {
+		var next []string
+		for _, uri := range level {
+			related, err := c.getRelatedConcepts(ctx, task, uri, relation)
+			if err != nil {
+				return nil, fmt.Errorf("failed walking %s of concept %s: %w", relation, conceptID, err)
+			}
+			for _, concept := range related {
+				edges[uri] = append(edges[uri], concept.ID)
+				if visited[concept.ID] {
+					continue
+				}
+				visited[concept.ID] = true
+				result = append(result, HierarchyConcept{Concept: concept, Depth: depth})
+				next = append(next, concept.ID)
+			}
+		}
+		level = next
+	}
+
+	if cycle := findCycle(startURI, edges); cycle != nil {
+		return nil, &HierarchyCycleError{
+			ConceptID: conceptID,
+			Relation:  relation,
+			Cycle:     cycle,
+		}
+	}
+	return result, nil
+}
+
+// getRelatedConcepts returns the concepts linked to the concept with the given URI by the relation.
+func (c *Client) getRelatedConcepts(ctx context.Context, task, conceptURI, relation string) ([]Concept, error) {
+	// Construct the request url. It looks like smartlogicURL?path=task:MyModel:Mytask/doubleEncodedConcept/skos:broader&properties=....
+	reqURL := c.baseAPIURL
+	reqURL.RawQuery = c.TaskQuery(task).
+		Resource(conceptURI).
+		Path(relation).
+		Properties(conceptProperties).
+		Encode()
+
+	resp, err := c.makeAuthorizedRequest(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	graph, err := readGraph(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var concepts []Concept
+	for _, data := range graph {
+		// The graph contains the label nodes as well, only the concept nodes are used.
+		var node jsonldNode
+		if err = json.Unmarshal(data, &node); err != nil {
+			return nil, fmt.Errorf("failed decoding response: %w", err)
+		}
+		types, err := node.types()
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding response: %w", err)
+		}
+		if !isConcept(types) {
+			continue
+		}
+
+		var concept Concept
+		if err = concept.unmarshalJSON(data, c.namespace); err != nil {
+			return nil, fmt.Errorf("failed decoding concept: %w", err)
+		}
+		concepts = append(concepts, concept)
+	}
+	return concepts, nil
+}
+
+// findCycle returns the URIs of a cycle reachable from the start through the edges, or nil if there is none.
+// The first URI of the cycle is repeated at its end.
+func findCycle(start string, edges map[string][]string) []string {
+	const (
+		unvisited = iota
+		inPath
+		done
+	)
+	state := map[string]int{}
+	var path []string
+
+	var visit func(uri string) []string
+	visit = func(uri string) []string {
+		state[uri] = inPath
+		path = append(path, uri)
+		for _, next := range edges[uri] {
+			switch state[next] {
+			case inPath:
+				for i, p := range path {
+					if p == next {
+						return append(append([]string(nil), path[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[uri] = done
+		return nil
+	}
+	return visit(start)
+}
@@ -0,0 +1,165 @@
+package smartlogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newHierarchyServer returns a server answering the requests for the relation, e.g. skos:broader,
+// with the given related concept UUIDs.
+func newHierarchyServer(t *testing.T, relation string, related map[string][]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/token" {
+			handleTokenRequest(t, w)
+			return
+		}
+		var nodes []string
+		for id, ids := range related {
+			path := "task:testModel:testTask/" + url.QueryEscape("<http://www.ft.com/thing/"+id+">") + "/" + relation
+			if req.URL.Query().Get("path") != path {
+				continue
+			}
+			for _, r := range ids {
+				nodes = append(nodes,
+					fmt.Sprintf(`{"@id":"http://www.ft.com/thing/%s","@type":["skos:Concept","http://www.ft.com/ontology/Topic"],"skosxl:prefLabel":[{"skosxl:literalForm":[{"@value":"%s","@language":"en"}]}]}`, r, r),
+					fmt.Sprintf(`{"@id":"http://www.ft.com/thing/%s/label","@type":["skosxl:Label"],"skosxl:literalForm":[{"@value":"%s","@language":"en"}]}`, r, r),
+				)
+			}
+		}
+		w.Header().Set("Content-Type", "application/ld+json")
+		_, err := w.Write([]byte(`{"@graph":[` + strings.Join(nodes, ",") + `]}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+}
+
+func TestClientGetAncestors(t *testing.T) {
+	testServer := newHierarchyServer(t, "skos:broader", map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"d"},
+	})
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	broader, err := client.GetBroaderConcepts(ctx, "testTask", "a")
+	if err != nil {
+		t.Fatalf("failed getting broader concepts: %v", err)
+	}
+	if got := hierarchyIDs(broader); !reflect.DeepEqual(got, []string{"b@1", "c@1"}) {
+		t.Errorf("unexpected broader concepts, got %v", got)
+	}
+
+	ancestors, err := client.GetAncestors(ctx, "testTask", "a")
+	if err != nil {
+		t.Fatalf("failed getting ancestors: %v", err)
+	}
+	if got := hierarchyIDs(ancestors); !reflect.DeepEqual(got, []string{"b@1", "c@1", "d@2"}) {
+		t.Errorf("unexpected ancestors, got %v", got)
+	}
+}
+
+func TestClientGetAncestorsCycle(t *testing.T) {
+	testServer := newHierarchyServer(t, "skos:broader", map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+	})
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	_, err = client.GetAncestors(ctx, "testTask", "a")
+	var cycleErr *HierarchyCycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected hierarchy cycle error, got %v", err)
+	}
+	expected := []string{"http://www.ft.com/thing/a", "http://www.ft.com/thing/b", "http://www.ft.com/thing/c", "http://www.ft.com/thing/a"}
+	if !reflect.DeepEqual(cycleErr.Cycle, expected) {
+		t.Errorf("unexpected cycle, got %v, want %v", cycleErr.Cycle, expected)
+	}
+}
+
+func TestClientGetDescendants(t *testing.T) {
+	testServer := newHierarchyServer(t, "skos:narrower", map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"d"},
+		"d": {"e"},
+	})
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	narrower, err := client.GetNarrowerConcepts(ctx, "testTask", "a")
+	if err != nil {
+		t.Fatalf("failed getting narrower concepts: %v", err)
+	}
+	if got := hierarchyIDs(narrower); !reflect.DeepEqual(got, []string{"b@1", "c@1"}) {
+		t.Errorf("unexpected narrower concepts, got %v", got)
+	}
+
+	descendants, err := client.GetDescendants(ctx, "testTask", "a")
+	if err != nil {
+		t.Fatalf("failed getting descendants: %v", err)
+	}
+	if got := hierarchyIDs(descendants); !reflect.DeepEqual(got, []string{"b@1", "c@1", "d@2", "e@3"}) {
+		t.Errorf("unexpected descendants, got %v", got)
+	}
+
+	// The broader relations are not served, so the walk must not follow them.
+	broader, err := client.GetBroaderConcepts(ctx, "testTask", "b")
+	if err != nil {
+		t.Fatalf("failed getting broader concepts: %v", err)
+	}
+	if len(broader) != 0 {
+		t.Errorf("unexpected broader concepts, got %v", hierarchyIDs(broader))
+	}
+}
+
+func hierarchyIDs(concepts []HierarchyConcept) []string {
+	var ids []string
+	for _, c := range concepts {
+		ids = append(ids, fmt.Sprintf("%s@%d", c.PrefLabel, c.Depth))
+	}
+	return ids
+}
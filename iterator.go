@@ -0,0 +1,166 @@
+package smartlogic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultPageSize is the number of concepts requested in a single page by the concept searches.
+const DefaultPageSize = 100
+
+// ErrPaginationUnsupported is returned by ConceptIterator when Smartlogic ignores the offset of the pages,
+// so that the results beyond the first page cannot be reached.
+var ErrPaginationUnsupported = errors.New("smartlogic: search pagination not supported")
+
+// ConceptIterator walks the concepts matching a search, requesting them from Smartlogic page by page,
+// so the whole result set is never loaded into memory. It is not safe for concurrent use.
+//
+//	it := client.IterateConceptsWithCustomMetadata(ctx, task, field, value, smartlogic.DefaultPageSize)
+//	for it.Next() {
+//		concept := it.Concept()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ConceptIterator struct {
+	ctx      context.Context
+	client   *Client
+	query    *Query
+	field    string
+	pageSize int
+
+	page      []json.RawMessage
+	offset    int
+	remaining int
+	lastPage  bool
+	// firstID is the @id of the first node of the previous page, used to detect the offset being ignored.
+	firstID string
+
+	node    json.RawMessage
+	concept ConceptSummary
+	err     error
+}
+
+// newConceptIterator returns iterator over the results of the query. The offset and limit of the query
+// are honoured, the pages are requested within them.
+func newConceptIterator(ctx context.Context, client *Client, query *Query, field string, pageSize int) *ConceptIterator {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	remaining := query.limit
+	if remaining <= 0 {
+		remaining = -1
+	}
+	return &ConceptIterator{
+		ctx:       ctx,
+		client:    client,
+		query:     query.clone(),
+		field:     field,
+		pageSize:  pageSize,
+		offset:    query.offset,
+		remaining: remaining,
+	}
+}
+
+// Next advances the iterator to the next concept, requesting the next page when needed.
+// It returns false when there are no more concepts or an error occurred, see Err.
+func (it *ConceptIterator) Next() bool {
+	if !it.next() {
+		return false
+	}
+
+	concept, err := decodeConceptSummary(it.node, it.field)
+	if err != nil {
+		it.err = fmt.Errorf("failed to read search response: %w", err)
+		return false
+	}
+	it.concept = concept
+	return true
+}
+
+// Concept returns the current concept.
+func (it *ConceptIterator) Concept() ConceptSummary {
+	return it.concept
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *ConceptIterator) Err() error {
+	return it.err
+}
+
+// next advances the iterator to the next undecoded node.
+func (it *ConceptIterator) next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if len(it.page) == 0 {
+		if it.lastPage {
+			return false
+		}
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			return false
+		}
+		if len(it.page) == 0 {
+			return false
+		}
+	}
+
+	it.node = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+func (it *ConceptIterator) fetchPage() error {
+	limit := it.pageSize
+	if it.remaining >= 0 && it.remaining < limit {
+		limit = it.remaining
+	}
+	if limit == 0 {
+		it.lastPage = true
+		return nil
+	}
+
+	page, err := it.client.searchConcepts(it.ctx, it.query.Limit(limit).Offset(it.offset))
+	if err != nil {
+		return err
+	}
+
+	if len(page) > 0 {
+		var first struct {
+			ID string `json:"@id"`
+		}
+		if err = json.Unmarshal(page[0], &first); err != nil {
+			return fmt.Errorf("failed to read search response: %w", err)
+		}
+		if it.offset > 0 && first.ID != "" && first.ID == it.firstID {
+			// The offset was ignored, so the following pages would repeat the first one forever.
+			return fmt.Errorf("%w: page at offset %d repeats the previous page", ErrPaginationUnsupported, it.offset)
+		}
+		it.firstID = first.ID
+	}
+
+	if len(page) > limit {
+		// The limit was ignored and the whole result set returned, so there are no more pages.
+		// Only the limit of the query itself is applied.
+		if it.remaining >= 0 && len(page) > it.remaining {
+			page = page[:it.remaining]
+		}
+		it.page = page
+		it.lastPage = true
+		return nil
+	}
+
+	it.page = page
+	it.offset += len(page)
+	if it.remaining >= 0 {
+		it.remaining -= len(page)
+	}
+	// Smartlogic returns less than the limit only on the last page.
+	it.lastPage = len(page) < limit
+	return nil
+}
@@ -0,0 +1,155 @@
+package smartlogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestConceptIteratorPagination(t *testing.T) {
+	const total = 5
+	var requests int
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			requests++
+			limit, err := strconv.Atoi(req.URL.Query().Get("limit"))
+			if err != nil {
+				t.Errorf("invalid limit param: %v", err)
+			}
+			var offset int
+			if o := req.URL.Query().Get("offset"); o != "" {
+				offset, err = strconv.Atoi(o)
+				if err != nil {
+					t.Errorf("invalid offset param: %v", err)
+				}
+			}
+			var nodes []string
+			for i := offset; i < total && i < offset+limit; i++ {
+				nodes = append(nodes, fmt.Sprintf(`{"@id":"http://www.ft.com/thing/%d","@type":["skos:Concept"],"meta:displayName":"Concept %d"}`, i, i))
+			}
+			w.Header().Set("Content-Type", "application/ld+json")
+			_, err = w.Write([]byte(`{"@graph":[` + strings.Join(nodes, ",") + `]}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	it := client.IterateConceptsWithCustomMetadata(ctx, "testTask", "http://www.ft.com/ontology/factsetIdentifier", "000C7F-E", 2)
+	var names []string
+	for it.Next() {
+		names = append(names, it.Concept().DisplayName)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("failed iterating concepts: %v", err)
+	}
+	if len(names) != total || names[0] != "Concept 0" || names[total-1] != "Concept 4" {
+		t.Errorf("unexpected concepts iterated: %v", names)
+	}
+	if requests != 3 {
+		t.Errorf("unexpected number of page requests, got %d, want 3", requests)
+	}
+}
+
+func TestConceptIteratorIgnoredPagination(t *testing.T) {
+	const total = 5
+	tests := []struct {
+		name             string
+		honourLimit      bool
+		expectedConcepts int
+		expectedRequests int
+		expectedError    error
+	}{
+		{
+			name:             "offset ignored",
+			honourLimit:      true,
+			expectedConcepts: 2,
+			expectedRequests: 2,
+			expectedError:    ErrPaginationUnsupported,
+		},
+		{
+			name:             "limit and offset ignored",
+			expectedConcepts: total,
+			expectedRequests: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests int
+			testServer := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					if req.URL.Path == "/token" {
+						handleTokenRequest(t, w)
+						return
+					}
+					requests++
+					if requests > 10 {
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+					n := total
+					if limit, err := strconv.Atoi(req.URL.Query().Get("limit")); err == nil && test.honourLimit && limit < n {
+						n = limit
+					}
+					var nodes []string
+					for i := 0; i < n; i++ {
+						nodes = append(nodes, fmt.Sprintf(`{"@id":"http://www.ft.com/thing/%d","@type":["skos:Concept"],"meta:displayName":"Concept %d"}`, i, i))
+					}
+					w.Header().Set("Content-Type", "application/ld+json")
+					if _, err := w.Write([]byte(`{"@graph":[` + strings.Join(nodes, ",") + `]}`)); err != nil {
+						t.Fatal(err)
+					}
+				}))
+			defer testServer.Close()
+
+			serverURL, err := url.Parse(testServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.TODO()
+
+			client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+			if err != nil {
+				t.Fatalf("failed creating Smartlogic client: %v", err)
+			}
+
+			it := client.IterateConceptsWithCustomMetadata(ctx, "testTask", "http://www.ft.com/ontology/factsetIdentifier", "000C7F-E", 2)
+			var concepts int
+			for it.Next() {
+				concepts++
+			}
+			if !errors.Is(it.Err(), test.expectedError) {
+				t.Errorf("unexpected iteration error, got %v, want %v", it.Err(), test.expectedError)
+			}
+			if concepts != test.expectedConcepts {
+				t.Errorf("unexpected number of concepts, got %d, want %d", concepts, test.expectedConcepts)
+			}
+			if requests != test.expectedRequests {
+				t.Errorf("unexpected number of page requests, got %d, want %d", requests, test.expectedRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package smartlogic
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// Datatypes of the typed metadata values.
+	DatatypeAnyURI = "xsd:anyURI"
+	DatatypeDate   = "xsd:date"
+)
+
+// MetadataValue is a literal value of a concept metadata field.
+// A value with neither Type nor Language is sent as a plain string.
+type MetadataValue struct {
+	Value    string
+	Type     string
+	Language string
+}
+
+// StringValue returns plain string metadata value.
+func StringValue(value string) MetadataValue {
+	return MetadataValue{Value: value}
+}
+
+// URIValue returns metadata value typed as xsd:anyURI.
+func URIValue(uri string) MetadataValue {
+	return MetadataValue{Value: uri, Type: DatatypeAnyURI}
+}
+
+// DateValue returns metadata value typed as xsd:date.
+func DateValue(date time.Time) MetadataValue {
+	return MetadataValue{Value: date.Format("2006-01-02"), Type: DatatypeDate}
+}
+
+// LanguageValue returns string metadata value tagged with the given language.
+func LanguageValue(value, language string) MetadataValue {
+	return MetadataValue{Value: value, Language: language}
+}
+
+func (v MetadataValue) MarshalJSON() ([]byte, error) {
+	if v.Type == "" && v.Language == "" {
+		return json.Marshal(v.Value)
+	}
+	return json.Marshal(struct {
+		Value    string `json:"@value"`
+		Type     string `json:"@type,omitempty"`
+		Language string `json:"@language,omitempty"`
+	}{v.Value, v.Type, v.Language})
+}
+
+func (v *MetadataValue) UnmarshalJSON(data []byte) error {
+	value := MetadataValue{}
+	if len(data) > 0 && data[0] == '{' {
+		var object struct {
+			Value    json.RawMessage `json:"@value"`
+			Type     string          `json:"@type"`
+			Language string          `json:"@language"`
+		}
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+		value.Type = object.Type
+		value.Language = object.Language
+		data = object.Value
+	}
+
+	s, err := literalString(data)
+	if err != nil {
+		return err
+	}
+	value.Value = s
+
+	*v = value
+	return nil
+}
+
+// metadataValues accepts metadata values given either as a single value or as an array of values.
+type metadataValues []MetadataValue
+
+func (values *metadataValues) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] != '[' {
+		var value MetadataValue
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*values = metadataValues{value}
+		return nil
+	}
+
+	var list []MetadataValue
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*values = list
+	return nil
+}
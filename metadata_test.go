@@ -0,0 +1,57 @@
+package smartlogic
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMetadataValueMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        MetadataValue
+		expectedJSON string
+	}{
+		{
+			name:         "plain string",
+			value:        StringValue("0DR49W-E"),
+			expectedJSON: `"0DR49W-E"`,
+		},
+		{
+			name:         "uri",
+			value:        URIValue("http://www.wikidata.org/entity/Q312"),
+			expectedJSON: `{"@value":"http://www.wikidata.org/entity/Q312","@type":"xsd:anyURI"}`,
+		},
+		{
+			name:         "date",
+			value:        DateValue(time.Date(2020, time.March, 4, 10, 0, 0, 0, time.UTC)),
+			expectedJSON: `{"@value":"2020-03-04","@type":"xsd:date"}`,
+		},
+		{
+			name:         "language tagged string",
+			value:        LanguageValue("Allemagne", "fr"),
+			expectedJSON: `{"@value":"Allemagne","@language":"fr"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			jsonRes, err := json.Marshal(test.value)
+			if err != nil {
+				t.Fatalf("unexpected error marshalling metadata value: %v", err)
+			}
+			if string(jsonRes) != test.expectedJSON {
+				t.Errorf("unexpected json returned, got %v, want %v", string(jsonRes), test.expectedJSON)
+			}
+
+			var decoded MetadataValue
+			err = json.Unmarshal(jsonRes, &decoded)
+			if err != nil {
+				t.Fatalf("unexpected error unmarshalling metadata value: %v", err)
+			}
+			if decoded != test.value {
+				t.Errorf("metadata value changed after round trip, got %+v, want %+v", decoded, test.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package smartlogic
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Namespace holds the IRIs under which the concepts and their properties are stored in a Smartlogic model.
+// The empty property IRIs default to MetadataFieldPrefix followed by the name of the FT Ontology property,
+// e.g. MetadataFieldPrefix/factsetIdentifier.
+type Namespace struct {
+	ConceptURIPrefix    string
+	MetadataFieldPrefix string
+
+	DescriptionProperty        string
+	TMEIdentifierProperty      string
+	FactsetIdentifierProperty  string
+	WikidataIdentifierProperty string
+	IndustryIdentifierProperty string
+	IsDeprecatedProperty       string
+}
+
+// FTNamespace is the namespace of the FT Ontology, used by default.
+var FTNamespace = Namespace{
+	ConceptURIPrefix:    ConceptURIPrefix,
+	MetadataFieldPrefix: MetadataFieldPrefix,
+}
+
+// defaultNamespace is FTNamespace with all the property IRIs filled.
+var defaultNamespace = FTNamespace.withDefaults()
+
+// withDefaults returns the namespace with the empty values filled from FTNamespace and MetadataFieldPrefix.
+func (n Namespace) withDefaults() Namespace {
+	if n.ConceptURIPrefix == "" {
+		n.ConceptURIPrefix = ConceptURIPrefix
+	}
+	if n.MetadataFieldPrefix == "" {
+		n.MetadataFieldPrefix = MetadataFieldPrefix
+	}
+
+	properties := []struct {
+		iri  *string
+		name string
+	}{
+		{&n.DescriptionProperty, "description"},
+		{&n.TMEIdentifierProperty, "TMEIdentifier"},
+		{&n.FactsetIdentifierProperty, "factsetIdentifier"},
+		{&n.WikidataIdentifierProperty, "wikidataIdentifier"},
+		{&n.IndustryIdentifierProperty, "industryIdentifier"},
+		{&n.IsDeprecatedProperty, "isDeprecated"},
+	}
+	for _, p := range properties {
+		if *p.iri == "" {
+			*p.iri = n.fieldURI(p.name)
+		}
+	}
+	return n
+}
+
+// conceptURI returns the IRI of the concept with the given UUID.
+func (n Namespace) conceptURI(conceptID string) string {
+	return n.ConceptURIPrefix + "/" + conceptID
+}
+
+// fieldURI returns the IRI of the metadata field with the given name.
+func (n Namespace) fieldURI(fieldName string) string {
+	return n.MetadataFieldPrefix + "/" + fieldName
+}
+
+// orderedObject is JSON object which keeps the order of its fields when marshalled,
+// used where the keys are not known at compile time.
+type orderedObject []objectField
+
+type objectField struct {
+	key   string
+	value interface{}
+}
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,130 @@
+package smartlogic
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNamespaceWithDefaults(t *testing.T) {
+	ns := Namespace{
+		ConceptURIPrefix:          "http://staging.ft.com/thing",
+		MetadataFieldPrefix:       "http://staging.ft.com/ontology",
+		FactsetIdentifierProperty: "http://staging.ft.com/ontology/factset",
+	}.withDefaults()
+
+	expected := Namespace{
+		ConceptURIPrefix:           "http://staging.ft.com/thing",
+		MetadataFieldPrefix:        "http://staging.ft.com/ontology",
+		DescriptionProperty:        "http://staging.ft.com/ontology/description",
+		TMEIdentifierProperty:      "http://staging.ft.com/ontology/TMEIdentifier",
+		FactsetIdentifierProperty:  "http://staging.ft.com/ontology/factset",
+		WikidataIdentifierProperty: "http://staging.ft.com/ontology/wikidataIdentifier",
+		IndustryIdentifierProperty: "http://staging.ft.com/ontology/industryIdentifier",
+		IsDeprecatedProperty:       "http://staging.ft.com/ontology/isDeprecated",
+	}
+	if !reflect.DeepEqual(ns, expected) {
+		t.Errorf("unexpected namespace, got %+v, want %+v", ns, expected)
+	}
+}
+
+func TestClientOptionsNamespaceOrder(t *testing.T) {
+	cfg := Config{ConceptURIPrefix: "http://config.ft.com/thing", MetadataFieldPrefix: "http://config.ft.com/ontology"}
+	ns := Namespace{ConceptURIPrefix: "http://staging.ft.com/thing"}
+	tests := []struct {
+		name                string
+		opts                []Option
+		expectedConceptURI  string
+		expectedFieldPrefix string
+	}{
+		{
+			name:                "namespace after config",
+			opts:                []Option{WithConfig(cfg), WithNamespace(ns)},
+			expectedConceptURI:  "http://staging.ft.com/thing",
+			expectedFieldPrefix: "http://config.ft.com/ontology",
+		},
+		{
+			name:                "config after namespace",
+			opts:                []Option{WithNamespace(ns), WithConfig(cfg)},
+			expectedConceptURI:  "http://config.ft.com/thing",
+			expectedFieldPrefix: "http://config.ft.com/ontology",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := clientOptions{}
+			for _, opt := range test.opts {
+				opt(&o)
+			}
+			got := o.clientNamespace()
+			if got.ConceptURIPrefix != test.expectedConceptURI {
+				t.Errorf("unexpected concept URI prefix, got %v, want %v", got.ConceptURIPrefix, test.expectedConceptURI)
+			}
+			if got.MetadataFieldPrefix != test.expectedFieldPrefix {
+				t.Errorf("unexpected metadata field prefix, got %v, want %v", got.MetadataFieldPrefix, test.expectedFieldPrefix)
+			}
+		})
+	}
+}
+
+func TestClientWithNamespace(t *testing.T) {
+	var createBody string
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			switch req.Method {
+			case http.MethodPost:
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Errorf("failed reading request body: %v", err)
+				}
+				createBody = string(body)
+			case http.MethodGet:
+				if req.URL.Query().Get("path") != "task:testModel:testTask/%3Chttp%3A%2F%2Fstaging.ft.com%2Fthing%2F7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0%3E" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/ld+json")
+				_, err := w.Write([]byte(`{"@graph":[{"@id":"http://staging.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0","@type":["skos:Concept","http://www.ft.com/ontology/organisation/Organisation"],"skosxl:prefLabel":[{"skosxl:literalForm":[{"@value":"Apple Inc","@language":"en"}]}],"http://staging.ft.com/ontology/factset":[{"@value":"000C7F-E"}]}]}`))
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+		}))
+	defer testServer.Close()
+
+	ctx := context.TODO()
+	client, err := New(ctx,
+		WithConfig(Config{BaseURL: testServer.URL, ClientID: "testClientID", Model: "testModel", ConceptURIPrefix: "http://staging.ft.com/thing"}),
+		WithHTTPClient(testServer.Client()),
+		WithTokenSource(StaticTokenSource("test_token")),
+		WithNamespace(Namespace{FactsetIdentifierProperty: "http://staging.ft.com/ontology/factset"}),
+	)
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	err = client.CreateConcept(ctx, Concept{
+		PrefLabel:         "Apple Inc",
+		Type:              TypeOrganisation,
+		SchemaObject:      ConceptSchemaOrganisation,
+		FactsetIdentifier: "000C7F-E",
+	}, "testTask")
+	if err != nil {
+		t.Fatalf("failed creating concept: %v", err)
+	}
+	expectedBody := `{"skosxl:prefLabel":[{"skosxl:literalForm":[{"@value":"Apple Inc","@language":"en"}],"@type":["skosxl:Label"]}],"@type":["skos:Concept","http://www.ft.com/ontology/organisation/Organisation"],"skos:topConceptOf":{"@id":"http://www.ft.com/ontology/scheme/Organisations"},"http://staging.ft.com/ontology/factset":[{"@value":"000C7F-E"}]}`
+	if createBody != expectedBody {
+		t.Errorf("unexpected body send on create concept, got %v, want %v", createBody, expectedBody)
+	}
+
+	concept, err := client.GetConcept(ctx, "testTask", "7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0")
+	if err != nil {
+		t.Fatalf("failed getting concept: %v", err)
+	}
+	if concept.FactsetIdentifier != "000C7F-E" {
+		t.Errorf("unexpected factset identifier, got %v, want 000C7F-E", concept.FactsetIdentifier)
+	}
+}
@@ -0,0 +1,30 @@
+// Package oauth2adapter adapts the golang.org/x/oauth2 token sources to be used by the Smartlogic client,
+// keeping the oauth2 dependency out of the core package.
+package oauth2adapter
+
+import (
+	"context"
+
+	smartlogic "github.com/Financial-Times/smartlogic-sdk"
+	"golang.org/x/oauth2"
+)
+
+// TokenSource adapts oauth2.TokenSource to be used as smartlogic.TokenSource.
+func TokenSource(src oauth2.TokenSource) smartlogic.TokenSource {
+	return tokenSource{src: src}
+}
+
+type tokenSource struct {
+	src oauth2.TokenSource
+}
+
+func (s tokenSource) Token(ctx context.Context) (*smartlogic.Token, error) {
+	token, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	return &smartlogic.Token{
+		AccessToken: token.AccessToken,
+		Expiry:      token.Expiry,
+	}, nil
+}
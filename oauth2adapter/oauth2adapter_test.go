@@ -0,0 +1,22 @@
+package oauth2adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestTokenSource(t *testing.T) {
+	expiry := time.Date(2020, time.March, 4, 10, 0, 0, 0, time.UTC)
+	src := TokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "oauth2_token", Expiry: expiry}))
+
+	token, err := src.Token(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected error getting token: %v", err)
+	}
+	if token.AccessToken != "oauth2_token" || !token.Expiry.Equal(expiry) {
+		t.Errorf("unexpected token returned, got %+v", token)
+	}
+}
@@ -0,0 +1,70 @@
+package smartlogic
+
+// WriteOption configures a single write request made by the Client.
+type WriteOption func(*writeOptions)
+
+type writeOptions struct {
+	acceptWarnings bool
+	retrySafe      bool
+}
+
+// AcceptWarnings makes Smartlogic apply the write request even if it reports warnings about it.
+func AcceptWarnings() WriteOption {
+	return func(o *writeOptions) {
+		o.acceptWarnings = true
+	}
+}
+
+// RetrySafe marks the write request as safe to be repeated, so it is retried on transient failures
+// according to the retry policy of the Client, like the idempotent requests are.
+func RetrySafe() WriteOption {
+	return func(o *writeOptions) {
+		o.retrySafe = true
+	}
+}
+
+func newWriteOptions(opts []WriteOption) writeOptions {
+	o := writeOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// BatchOption configures a batch operation of the Client.
+type BatchOption func(*batchOptions)
+
+type batchOptions struct {
+	batchSize   int
+	concurrency int
+	write       []WriteOption
+}
+
+// BatchSize sets the number of concepts sent in a single request by the batch operations.
+func BatchSize(size int) BatchOption {
+	return func(o *batchOptions) {
+		o.batchSize = size
+	}
+}
+
+// Concurrency sets the number of requests made in parallel by the batch operations.
+func Concurrency(n int) BatchOption {
+	return func(o *batchOptions) {
+		o.concurrency = n
+	}
+}
+
+// WithWriteOptions applies the write options to every write request made by the batch operation.
+func WithWriteOptions(opts ...WriteOption) BatchOption {
+	return func(o *batchOptions) {
+		o.write = append(o.write, opts...)
+	}
+}
+
+func newBatchOptions(opts []BatchOption) batchOptions {
+	o := batchOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
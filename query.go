@@ -0,0 +1,233 @@
+package smartlogic
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Query describes a Smartlogic read request: the path of the resources, the properties returned for them,
+// the filters they must match, their sorting and the page of the results.
+// Every method modifies the query and returns it, so that the calls can be chained:
+//
+//	q := client.TaskQuery(task).
+//		Path("skos:Concept", "meta:transitiveInstance").
+//		Properties("rdf:type", "meta:displayName").
+//		Filter(Equals(field, value)).
+//		Limit(10)
+type Query struct {
+	path       []string
+	properties []string
+	filter     Filter
+	sort       []string
+	limit      int
+	offset     int
+}
+
+// NewQuery returns a query for the given path segments, e.g. "model:MyModel".
+func NewQuery(path ...string) *Query {
+	return (&Query{}).Path(path...)
+}
+
+// TaskQuery returns a query for the path of the given task in the model of the client.
+func (c *Client) TaskQuery(task string) *Query {
+	return NewQuery("task:" + c.model + ":" + task)
+}
+
+// Path appends prefixed names, e.g. "skos:Concept", to the path of the query.
+func (q *Query) Path(segments ...string) *Query {
+	for _, s := range segments {
+		// The colons of the prefixed names are kept readable, as in the examples of Smartlogic.
+		q.path = append(q.path, strings.ReplaceAll(url.QueryEscape(s), "%3A", ":"))
+	}
+	return q
+}
+
+// Resource appends the resource with the given IRI, e.g. a concept URI, to the path of the query.
+// Smartlogic API requires the IRI to be inside < > and escaped twice.
+func (q *Query) Resource(iri string) *Query {
+	q.path = append(q.path, url.QueryEscape(url.QueryEscape("<"+iri+">")))
+	return q
+}
+
+// Properties selects the properties returned for the resources, "[]" selects all of them.
+func (q *Query) Properties(properties ...string) *Query {
+	q.properties = append(q.properties, properties...)
+	return q
+}
+
+// Filter adds a filter the resources must match. Multiple filters are combined with And.
+func (q *Query) Filter(f Filter) *Query {
+	if q.filter.expr == "" && q.filter.err == nil {
+		q.filter = f
+	} else {
+		q.filter = And(q.filter, f)
+	}
+	return q
+}
+
+// SortBy sorts the results by the given property, in descending order when desc is true.
+// The results are sorted by the properties in the order the calls were made.
+func (q *Query) SortBy(property string, desc bool) *Query {
+	s := propertyRef(property)
+	if desc {
+		s += " desc"
+	}
+	q.sort = append(q.sort, s)
+	return q
+}
+
+// Limit sets the maximum number of results, zero means no limit.
+func (q *Query) Limit(n int) *Query {
+	q.limit = n
+	return q
+}
+
+// Offset sets the number of results skipped.
+func (q *Query) Offset(n int) *Query {
+	q.offset = n
+	return q
+}
+
+// Err returns the error of an invalid filter of the query, e.g. a malformed language tag.
+// The query is not sent to Smartlogic when it is invalid.
+func (q *Query) Err() error {
+	return q.filter.err
+}
+
+// Encode renders the query as the raw query string of the request URL.
+// The path param is written as it is, because its resource segments are already escaped.
+func (q *Query) Encode() string {
+	var b strings.Builder
+	b.WriteString("path=")
+	b.WriteString(strings.Join(q.path, "/"))
+
+	writeParam := func(name, value string) {
+		if value != "" {
+			b.WriteString("&" + name + "=" + url.QueryEscape(value))
+		}
+	}
+	writeParam("properties", strings.Join(q.properties, ","))
+	writeParam("filters", q.filter.String())
+	writeParam("sort", strings.Join(q.sort, ","))
+	if q.limit > 0 {
+		writeParam("limit", strconv.Itoa(q.limit))
+	}
+	if q.offset > 0 {
+		writeParam("offset", strconv.Itoa(q.offset))
+	}
+	return b.String()
+}
+
+// clone returns a copy of the query which can be modified independently.
+func (q *Query) clone() *Query {
+	c := *q
+	c.path = append([]string(nil), q.path...)
+	c.properties = append([]string(nil), q.properties...)
+	c.sort = append([]string(nil), q.sort...)
+	return &c
+}
+
+// Filter is a condition on the properties of the resources of a query.
+// The zero Filter matches all resources.
+type Filter struct {
+	expr string
+	err  error
+}
+
+// Equals matches the resources with the given string value of the property.
+func Equals(property, value string) Filter {
+	return Filter{expr: propertyRef(property) + "=" + quoteLiteral(value)}
+}
+
+// EqualsResource matches the resources with the given IRI as value of the property.
+// The characters not allowed inside < > are percent-encoded.
+func EqualsResource(property, iri string) Filter {
+	return Filter{expr: propertyRef(property) + "=<" + escapeIRI(iri) + ">"}
+}
+
+// InLanguage matches the resources with the given value of the property in the given language.
+// The filter is invalid, failing the query, when the language is not a well-formed language tag, e.g. "en-GB".
+func InLanguage(property, value, language string) Filter {
+	if !languageTagPattern.MatchString(language) {
+		return Filter{err: fmt.Errorf("invalid language tag %q", language)}
+	}
+	return Filter{expr: propertyRef(property) + "=" + quoteLiteral(value) + "@" + language}
+}
+
+// Regex matches the resources with a value of the property matching the regular expression.
+func Regex(property, pattern string) Filter {
+	return Filter{expr: "regex(" + propertyRef(property) + "," + quoteLiteral(pattern) + ")"}
+}
+
+// And matches the resources matching all the filters.
+func And(filters ...Filter) Filter {
+	return combineFilters("&&", filters)
+}
+
+// Or matches the resources matching any of the filters.
+func Or(filters ...Filter) Filter {
+	return combineFilters("||", filters)
+}
+
+// String renders the filter as the value of the filters query param.
+func (f Filter) String() string {
+	if f.expr == "" {
+		return ""
+	}
+	return "subject(" + f.expr + ")"
+}
+
+func combineFilters(op string, filters []Filter) Filter {
+	var exprs []string
+	for _, f := range filters {
+		if f.err != nil {
+			return Filter{err: f.err}
+		}
+		if f.expr != "" {
+			exprs = append(exprs, f.expr)
+		}
+	}
+	switch len(exprs) {
+	case 0:
+		return Filter{}
+	case 1:
+		return Filter{expr: exprs[0]}
+	}
+	return Filter{expr: "(" + strings.Join(exprs, op) + ")"}
+}
+
+// propertyRef returns the reference to the property in a filter. Full IRIs are put inside < >,
+// prefixed names, e.g. "meta:displayName", are used as they are.
+func propertyRef(property string) string {
+	if strings.Contains(property, "://") {
+		return "<" + property + ">"
+	}
+	return property
+}
+
+var literalEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// quoteLiteral returns the value as a quoted string literal, escaping the quotes inside it.
+func quoteLiteral(value string) string {
+	return `"` + literalEscaper.Replace(value) + `"`
+}
+
+var languageTagPattern = regexp.MustCompile(`^[A-Za-z]{1,8}(-[A-Za-z0-9]{1,8})*$`)
+
+// escapeIRI percent-encodes the characters of the IRI which are not allowed inside < >: the control characters,
+// the space and <>"{}|^`\.
+func escapeIRI(iri string) string {
+	var b strings.Builder
+	for i := 0; i < len(iri); i++ {
+		c := iri[i]
+		if c <= ' ' || strings.IndexByte("<>\"{}|^`\\", c) >= 0 {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,127 @@
+package smartlogic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQueryEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *Query
+		expected string
+	}{
+		{
+			name:     "path",
+			query:    NewQuery("task:MyModel:MyTask", "skos:Concept", "rdf:instance"),
+			expected: "path=task:MyModel:MyTask/skos:Concept/rdf:instance",
+		},
+		{
+			name:     "resource",
+			query:    NewQuery("task:MyModel:MyTask").Resource("http://www.ft.com/thing/7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0"),
+			expected: "path=task:MyModel:MyTask/%253Chttp%253A%252F%252Fwww.ft.com%252Fthing%252F7bcfe07b-0fb1-49ce-a5fa-e51d5c01c3e0%253E",
+		},
+		{
+			name: "properties, filter, sorting and page",
+			query: NewQuery("task:MyModel:MyTask").
+				Properties("rdf:type", "meta:displayName").
+				Filter(Equals("http://www.ft.com/ontology/factsetIdentifier", "000C7F-E")).
+				SortBy("meta:displayName", true).
+				Limit(10).
+				Offset(20),
+			expected: "path=task:MyModel:MyTask&properties=rdf%3Atype%2Cmeta%3AdisplayName" +
+				"&filters=subject%28%3Chttp%3A%2F%2Fwww.ft.com%2Fontology%2FfactsetIdentifier%3E%3D%22000C7F-E%22%29" +
+				"&sort=meta%3AdisplayName+desc&limit=10&offset=20",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.query.Encode(); got != test.expected {
+				t.Errorf("unexpected encoded query, got %s, want %s", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestFilterString(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected string
+	}{
+		{
+			name:     "escaped quotes",
+			filter:   Equals("meta:displayName", `The "Apple" \ Inc`),
+			expected: `subject(meta:displayName="The \"Apple\" \\ Inc")`,
+		},
+		{
+			name:     "resource",
+			filter:   EqualsResource("rdf:type", "http://www.ft.com/ontology/organisation/Organisation"),
+			expected: `subject(rdf:type=<http://www.ft.com/ontology/organisation/Organisation>)`,
+		},
+		{
+			name:     "resource with characters not allowed in IRI",
+			filter:   EqualsResource("rdf:type", "http://www.ft.com/a b>) || <c"),
+			expected: `subject(rdf:type=<http://www.ft.com/a%20b%3E)%20%7C%7C%20%3Cc>)`,
+		},
+		{
+			name:     "language",
+			filter:   InLanguage("skosxl:prefLabel/skosxl:literalForm", "Apple", "en"),
+			expected: `subject(skosxl:prefLabel/skosxl:literalForm="Apple"@en)`,
+		},
+		{
+			name:     "language with region",
+			filter:   InLanguage("skosxl:prefLabel/skosxl:literalForm", "Apple", "en-GB"),
+			expected: `subject(skosxl:prefLabel/skosxl:literalForm="Apple"@en-GB)`,
+		},
+		{
+			name:     "regex",
+			filter:   Regex("meta:displayName", "^Apple"),
+			expected: `subject(regex(meta:displayName,"^Apple"))`,
+		},
+		{
+			name: "combined",
+			filter: Or(
+				And(Equals("meta:displayName", "Apple"), Regex("meta:displayName", "Inc$")),
+				Equals("meta:displayName", "Alphabet"),
+			),
+			expected: `subject(((meta:displayName="Apple"&&regex(meta:displayName,"Inc$"))||meta:displayName="Alphabet"))`,
+		},
+		{
+			name:     "empty",
+			filter:   And(),
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.filter.String(); got != test.expected {
+				t.Errorf("unexpected filter, got %s, want %s", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestQueryInvalidLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+	}{
+		{name: "empty", language: ""},
+		{name: "filter injection", language: "en)||subject(meta:displayName=\"Apple\""},
+		{name: "space", language: "en GB"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query := NewQuery("task:MyModel:MyTask").
+				Filter(InLanguage("skosxl:prefLabel/skosxl:literalForm", "Apple", test.language)).
+				Filter(Equals("meta:displayName", "Apple"))
+			if query.Err() == nil {
+				t.Errorf("expected error for language tag %q", test.language)
+			}
+			if strings.Contains(query.Encode(), "filters=") {
+				t.Errorf("unexpected filter of invalid query: %s", query.Encode())
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package smartlogic
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OperationClass groups the requests sharing a rate limit.
+type OperationClass int
+
+const (
+	// OperationRead are the GET requests.
+	OperationRead OperationClass = iota
+	// OperationWrite are all the other requests.
+	OperationWrite
+)
+
+func (c OperationClass) String() string {
+	if c == OperationRead {
+		return "read"
+	}
+	return "write"
+}
+
+// RateLimit is a token bucket limit allowing RequestsPerSecond on average with bursts of up to Burst requests.
+// Zero RequestsPerSecond means no limit.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// RateLimits are the limits of the requests made by the Client per operation class.
+type RateLimits struct {
+	Read  RateLimit
+	Write RateLimit
+}
+
+// RateLimitStats are the counters of the requests which passed through the rate limiter of one operation class.
+type RateLimitStats struct {
+	Requests int64
+	// Delayed is the number of requests which had to wait for the limiter.
+	Delayed  int64
+	WaitTime time.Duration
+}
+
+// RateLimitObserver is called after each request passed through the rate limiter with the time it waited,
+// e.g. to record metrics.
+type RateLimitObserver func(class OperationClass, wait time.Duration)
+
+// rateLimiter limits the requests of all the goroutines using the Client, it is safe for concurrent use.
+type rateLimiter struct {
+	buckets  [2]*tokenBucket
+	stats    [2]rateLimitCounters
+	observer RateLimitObserver
+}
+
+type rateLimitCounters struct {
+	requests  int64
+	delayed   int64
+	waitNanos int64
+}
+
+func newRateLimiter(limits RateLimits, observer RateLimitObserver) *rateLimiter {
+	return &rateLimiter{
+		buckets: [2]*tokenBucket{
+			OperationRead:  newTokenBucket(limits.Read),
+			OperationWrite: newTokenBucket(limits.Write),
+		},
+		observer: observer,
+	}
+}
+
+// Wait blocks until the request of the given class is allowed or the context is done.
+func (l *rateLimiter) Wait(ctx context.Context, class OperationClass) error {
+	wait, err := l.buckets[class].wait(ctx)
+	if err != nil {
+		return err
+	}
+
+	counters := &l.stats[class]
+	atomic.AddInt64(&counters.requests, 1)
+	if wait > 0 {
+		atomic.AddInt64(&counters.delayed, 1)
+		atomic.AddInt64(&counters.waitNanos, int64(wait))
+	}
+	if l.observer != nil {
+		l.observer(class, wait)
+	}
+	return nil
+}
+
+func (l *rateLimiter) Stats(class OperationClass) RateLimitStats {
+	counters := &l.stats[class]
+	return RateLimitStats{
+		Requests: atomic.LoadInt64(&counters.requests),
+		Delayed:  atomic.LoadInt64(&counters.delayed),
+		WaitTime: time.Duration(atomic.LoadInt64(&counters.waitNanos)),
+	}
+}
+
+// operationClass returns the operation class of the request with the given method.
+func operationClass(method string) OperationClass {
+	if method == http.MethodGet || method == http.MethodHead {
+		return OperationRead
+	}
+	return OperationWrite
+}
+
+// tokenBucket is a token bucket refilled with rate tokens per second up to burst tokens.
+// Nil tokenBucket does not limit.
+type tokenBucket struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		now:    time.Now,
+		tokens: burst,
+	}
+}
+
+// reserve takes a token from the bucket and returns how long to wait until it is available.
+// The tokens go negative when they are reserved ahead of time.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns the reserved token which was not used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+}
+
+// wait blocks until a token is available and returns how long it waited for it.
+func (b *tokenBucket) wait(ctx context.Context) (time.Duration, error) {
+	if b == nil {
+		return 0, nil
+	}
+
+	wait := b.reserve()
+	if wait <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return wait, nil
+	case <-ctx.Done():
+		b.cancel()
+		return 0, ctx.Err()
+	}
+}
@@ -0,0 +1,94 @@
+package smartlogic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	now := time.Date(2020, time.March, 4, 10, 0, 0, 0, time.UTC)
+	b := newTokenBucket(RateLimit{RequestsPerSecond: 10, Burst: 2})
+	b.now = func() time.Time { return now }
+
+	expectedWaits := []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond}
+	for i, expected := range expectedWaits {
+		if wait := b.reserve(); wait != expected {
+			t.Errorf("unexpected wait for request %d, got %v, want %v", i, wait, expected)
+		}
+	}
+
+	// After a second the bucket is refilled up to the burst.
+	now = now.Add(time.Second)
+	if wait := b.reserve(); wait != 0 {
+		t.Errorf("unexpected wait after refill, got %v, want 0", wait)
+	}
+
+	if newTokenBucket(RateLimit{}) != nil {
+		t.Errorf("expected no bucket for zero rate limit")
+	}
+}
+
+func TestClientRateLimits(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	defer testServer.Close()
+
+	var mu sync.Mutex
+	observed := map[OperationClass]int{}
+	ctx := context.TODO()
+	client, err := New(ctx,
+		WithHTTPClient(testServer.Client()),
+		WithBaseURL(testServer.URL),
+		WithClientID("testClientID"),
+		WithModel("testModel"),
+		WithTokenSource(StaticTokenSource("test_token")),
+		WithRateLimits(RateLimits{
+			Read: RateLimit{RequestsPerSecond: 100, Burst: 1},
+		}),
+		WithRateLimitObserver(func(class OperationClass, wait time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			observed[class]++
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Ping(ctx); err != nil {
+				t.Errorf("unexpected error pinging Smartlogic: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	// The first request uses the burst and the other four wait 10ms each.
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("requests were not rate limited, took %v", elapsed)
+	}
+
+	err = client.AddConceptMetadataField(ctx, "conceptID", "factsetIdentifier", "factsetID", "testTask")
+	if err != nil {
+		t.Errorf("unexpected error adding concept metadata field: %v", err)
+	}
+
+	readStats := client.RateLimitStats(OperationRead)
+	if readStats.Requests != 5 || readStats.Delayed != 4 || readStats.WaitTime <= 0 {
+		t.Errorf("unexpected read stats: %+v", readStats)
+	}
+	writeStats := client.RateLimitStats(OperationWrite)
+	if writeStats.Requests != 1 || writeStats.Delayed != 0 {
+		t.Errorf("unexpected write stats: %+v", writeStats)
+	}
+	if observed[OperationRead] != 5 || observed[OperationWrite] != 1 {
+		t.Errorf("unexpected observed requests: %v", observed)
+	}
+}
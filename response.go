@@ -0,0 +1,60 @@
+package smartlogic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+)
+
+// MaxResponseBodySize limits how much of a read response body is loaded into memory.
+const MaxResponseBodySize = 32 * 1024 * 1024
+
+// Errors returned when a successful read response cannot be used.
+var (
+	ErrUnexpectedContentType = errors.New("smartlogic: unexpected content type")
+	ErrResponseTooLarge      = errors.New("smartlogic: response too large")
+)
+
+// readGraph validates the response of a read request and returns the nodes of its @graph.
+// Unexpected statuses are returned as APIError, the content type must be JSON-LD or JSON
+// and the body must not be larger than MaxResponseBodySize. The body is not closed.
+func readGraph(resp *http.Response) ([]json.RawMessage, error) {
+	data, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var graph struct {
+		Graph []json.RawMessage `json:"@graph"`
+	}
+	if err = json.Unmarshal(data, &graph); err != nil {
+		return nil, fmt.Errorf("failed decoding response: %w", err)
+	}
+	return graph.Graph, nil
+}
+
+// readResponse validates the response of a read request and returns its body.
+func readResponse(resp *http.Response) ([]byte, error) {
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || (mediaType != "application/ld+json" && mediaType != "application/json") {
+		return nil, fmt.Errorf("%w %q", ErrUnexpectedContentType, contentType)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, MaxResponseBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed reading response: %w", err)
+	}
+	if len(data) > MaxResponseBodySize {
+		return nil, fmt.Errorf("%w, exceeds %d bytes", ErrResponseTooLarge, MaxResponseBodySize)
+	}
+	return data, nil
+}
@@ -0,0 +1,78 @@
+package smartlogic
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestReadGraph(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		contentType   string
+		body          string
+		expectedNodes int
+		expectedError error
+	}{
+		{
+			name:          "json-ld",
+			status:        http.StatusOK,
+			contentType:   "application/ld+json; charset=utf-8",
+			body:          `{"@graph":[{"@id":"a"},{"@id":"b"}]}`,
+			expectedNodes: 2,
+		},
+		{
+			name:          "json",
+			status:        http.StatusOK,
+			contentType:   "application/json",
+			body:          `{"@graph":[{"@id":"a"}]}`,
+			expectedNodes: 1,
+		},
+		{
+			name:          "not found html page",
+			status:        http.StatusNotFound,
+			contentType:   "text/html",
+			body:          "<html>Not Found</html>",
+			expectedError: ErrNotFound,
+		},
+		{
+			name:          "html page with ok status",
+			status:        http.StatusOK,
+			contentType:   "text/html",
+			body:          "<html>Maintenance</html>",
+			expectedError: ErrUnexpectedContentType,
+		},
+		{
+			name:          "too large",
+			status:        http.StatusOK,
+			contentType:   "application/ld+json",
+			body:          `{"@graph":[` + strings.Repeat(" ", MaxResponseBodySize) + `]}`,
+			expectedError: ErrResponseTooLarge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: test.status,
+				Header:     http.Header{"Content-Type": []string{test.contentType}},
+				Body:       ioutil.NopCloser(strings.NewReader(test.body)),
+			}
+			nodes, err := readGraph(resp)
+			if test.expectedError != nil {
+				if !errors.Is(err, test.expectedError) {
+					t.Errorf("unexpected error, got %v, want %v", err, test.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(nodes) != test.expectedNodes {
+				t.Errorf("unexpected number of nodes, got %d, want %d", len(nodes), test.expectedNodes)
+			}
+		})
+	}
+}
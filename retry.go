@@ -0,0 +1,129 @@
+package smartlogic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures retrying of the requests which failed for transient reasons: connection resets, timeouts
+// and 429, 502, 503 and 504 responses. Only the idempotent requests and the writes marked with RetrySafe are retried.
+// The zero RetryPolicy disables the retries.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the first attempt.
+	MaxRetries int
+	// InitialBackoff is the wait before the first retry, it doubles with each next retry up to MaxBackoff.
+	// The actual waits are jittered between the half and the full backoff.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the backoff. Responses asking with Retry-After for a longer wait are not retried.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is a retry policy suitable for most Smartlogic Cloud clients.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+}
+
+// backoff returns the wait before the given retry, counted from zero. A Retry-After header of the response
+// takes precedence over the computed backoff. It returns false when Retry-After asks for a longer wait than
+// MaxBackoff, so that the request is not retried instead of blocking the caller for that long.
+func (p RetryPolicy) backoff(retry int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+				return 0, false
+			}
+			return wait, true
+		}
+	}
+
+	backoff := p.InitialBackoff
+	for i := 0; i < retry && (p.MaxBackoff <= 0 || backoff < p.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1)), true
+}
+
+// retryAfter parses the value of Retry-After header given either in seconds or as http date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// isTransientFailure reports whether the request failed for reason which may go away when it is retried.
+func isTransientFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return true
+		}
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout()
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotent reports whether the request with the given method can be safely repeated.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// waitForRetry waits before the retry, unless the context is done first or its deadline would pass during the wait.
+func waitForRetry(ctx context.Context, wait time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+		return false
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// discardBody reads the rest of the body, so the connection can be reused, and closes it.
+func discardBody(resp *http.Response) {
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+	resp.Body.Close()
+}
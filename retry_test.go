@@ -0,0 +1,177 @@
+package smartlogic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientRetriesTransientFailures(t *testing.T) {
+	tests := []struct {
+		name             string
+		failures         int
+		failureStatus    int
+		retryAfter       string
+		closeConnection  bool
+		maxBackoff       time.Duration
+		write            bool
+		opts             []WriteOption
+		timeout          time.Duration
+		expectedRequests int
+		expectedError    bool
+	}{
+		{
+			name:             "read retried after 503",
+			failures:         2,
+			failureStatus:    http.StatusServiceUnavailable,
+			expectedRequests: 3,
+			expectedError:    false,
+		},
+		{
+			name:             "read retried after 429 with Retry-After",
+			failures:         1,
+			failureStatus:    http.StatusTooManyRequests,
+			retryAfter:       "0",
+			expectedRequests: 2,
+			expectedError:    false,
+		},
+		{
+			name:             "read retried after connection closed",
+			failures:         1,
+			closeConnection:  true,
+			expectedRequests: 2,
+			expectedError:    false,
+		},
+		{
+			name:             "read not retried after 500",
+			failures:         1,
+			failureStatus:    http.StatusInternalServerError,
+			expectedRequests: 1,
+			expectedError:    true,
+		},
+		{
+			name:             "read fails after max retries",
+			failures:         5,
+			failureStatus:    http.StatusBadGateway,
+			expectedRequests: 4,
+			expectedError:    true,
+		},
+		{
+			name:             "write not retried",
+			failures:         1,
+			failureStatus:    http.StatusServiceUnavailable,
+			write:            true,
+			expectedRequests: 1,
+			expectedError:    true,
+		},
+		{
+			name:             "write marked safe retried",
+			failures:         1,
+			failureStatus:    http.StatusServiceUnavailable,
+			write:            true,
+			opts:             []WriteOption{RetrySafe()},
+			expectedRequests: 2,
+			expectedError:    false,
+		},
+		{
+			name:             "Retry-After beyond context deadline",
+			failures:         1,
+			failureStatus:    http.StatusTooManyRequests,
+			retryAfter:       "60",
+			maxBackoff:       time.Hour,
+			timeout:          time.Second,
+			expectedRequests: 1,
+			expectedError:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests := 0
+			testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				requests++
+				if requests <= test.failures {
+					if test.closeConnection {
+						conn, _, err := w.(http.Hijacker).Hijack()
+						if err != nil {
+							t.Fatal(err)
+						}
+						conn.Close()
+						return
+					}
+					if test.retryAfter != "" {
+						w.Header().Set("Retry-After", test.retryAfter)
+					}
+					w.WriteHeader(test.failureStatus)
+				}
+			}))
+			defer testServer.Close()
+
+			ctx := context.Background()
+			if test.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+			}
+
+			maxBackoff := test.maxBackoff
+			if maxBackoff == 0 {
+				maxBackoff = 5 * time.Millisecond
+			}
+			client, err := New(ctx,
+				WithHTTPClient(testServer.Client()),
+				WithBaseURL(testServer.URL),
+				WithClientID("testClientID"),
+				WithModel("testModel"),
+				WithTokenSource(StaticTokenSource("test_token")),
+				WithRetryPolicy(RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: maxBackoff}),
+			)
+			if err != nil {
+				t.Fatalf("failed creating Smartlogic client: %v", err)
+			}
+
+			start := time.Now()
+			if test.write {
+				err = client.AddConceptMetadataField(ctx, "conceptID", "factsetIdentifier", "factsetID", "testTask", test.opts...)
+			} else {
+				err = client.Ping(ctx)
+			}
+			if err != nil && !test.expectedError {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if err == nil && test.expectedError {
+				t.Errorf("expected error")
+			}
+			// The client gives up straight away instead of waiting for the deadline.
+			if test.timeout > 0 && time.Since(start) >= test.timeout/2 {
+				t.Errorf("unexpected wait for the context deadline, took %v", time.Since(start))
+			}
+			if requests != test.expectedRequests {
+				t.Errorf("unexpected number of requests, got %d, want %d", requests, test.expectedRequests)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	expectedMax := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for retry, max := range expectedMax {
+		backoff, ok := policy.backoff(retry, nil)
+		if !ok || backoff < max/2 || backoff > max {
+			t.Errorf("unexpected backoff for retry %d, got %v, want between %v and %v", retry, backoff, max/2, max)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"1"}}}
+	if backoff, ok := policy.backoff(0, resp); !ok || backoff != time.Second {
+		t.Errorf("unexpected backoff with Retry-After, got %v, want 1s", backoff)
+	}
+
+	// Retry-After longer than MaxBackoff is not waited for.
+	resp = &http.Response{Header: http.Header{"Retry-After": []string{"3600"}}}
+	if backoff, ok := policy.backoff(0, resp); ok {
+		t.Errorf("unexpected retry with Retry-After above MaxBackoff, got backoff %v", backoff)
+	}
+}
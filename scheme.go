@@ -0,0 +1,134 @@
+package smartlogic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ConceptScheme is a scheme the concepts of a model are organised in, see Concept.SchemaObject.
+type ConceptScheme struct {
+	ID        string
+	PrefLabel string
+}
+
+// inputConceptScheme is helper struct matching the input format for creating new concept scheme in the Smartlogic API.
+type inputConceptScheme struct {
+	ID        string         `json:"@id"`
+	Type      []string       `json:"@type"`
+	PrefLabel []conceptLabel `json:"skosxl:prefLabel"`
+}
+
+// ListConceptSchemes returns the concept schemes defined in the model of the task.
+func (c *Client) ListConceptSchemes(ctx context.Context, task string) ([]ConceptScheme, error) {
+	query := c.TaskQuery(task).
+		Path("skos:ConceptScheme", "meta:transitiveInstance").
+		Properties("meta:displayName", "skosxl:prefLabel/skosxl:literalForm")
+
+	graph, err := c.searchConcepts(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing concept schemes: %w", err)
+	}
+
+	var schemes []ConceptScheme
+	for _, data := range graph {
+		var node jsonldNode
+		if err = json.Unmarshal(data, &node); err != nil {
+			return nil, fmt.Errorf("failed decoding concept scheme: %w", err)
+		}
+
+		scheme := ConceptScheme{}
+		if err = node.decode("@id", &scheme.ID); err != nil {
+			return nil, fmt.Errorf("failed decoding concept scheme: %w", err)
+		}
+		labels, err := node.labels("skosxl:prefLabel", skosxlPrefix+"prefLabel")
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding concept scheme %s: %w", scheme.ID, err)
+		}
+		if len(labels) == 0 {
+			var displayNames wordValues
+			if err = node.decode("meta:displayName", &displayNames); err != nil {
+				return nil, fmt.Errorf("failed decoding concept scheme %s: %w", scheme.ID, err)
+			}
+			labels = englishValues(displayNames)
+		}
+		if len(labels) > 0 {
+			scheme.PrefLabel = labels[0]
+		}
+		schemes = append(schemes, scheme)
+	}
+	return schemes, nil
+}
+
+// GetTopConcepts returns the top concepts of the concept scheme with the given IRI.
+func (c *Client) GetTopConcepts(ctx context.Context, task, schemeIRI string) ([]Concept, error) {
+	concepts, err := c.getRelatedConcepts(ctx, task, schemeIRI, "skos:hasTopConcept")
+	if err != nil {
+		return nil, fmt.Errorf("failed getting top concepts of %s: %w", schemeIRI, err)
+	}
+	return concepts, nil
+}
+
+// ValidateSchemaObject checks that the concept scheme of the concept exists in the model of the task,
+// so that the concept can be created under it. Concepts without scheme are not checked.
+// ErrNotFound is returned when the scheme does not exist.
+func (c *Client) ValidateSchemaObject(ctx context.Context, task string, concept Concept) error {
+	if concept.SchemaObject == "" {
+		return nil
+	}
+
+	schemes, err := c.ListConceptSchemes(ctx, task)
+	if err != nil {
+		return err
+	}
+	for _, scheme := range schemes {
+		if scheme.ID == concept.SchemaObject {
+			return nil
+		}
+	}
+	return fmt.Errorf("concept scheme %s: %w", concept.SchemaObject, ErrNotFound)
+}
+
+// CreateConceptScheme creates new concept scheme with the given IRI and label in the model of the task.
+func (c *Client) CreateConceptScheme(ctx context.Context, task string, scheme ConceptScheme, opts ...WriteOption) error {
+	if scheme.ID == "" {
+		return errors.New("input concept scheme should have id defined")
+	}
+	if scheme.PrefLabel == "" {
+		return errors.New("input concept scheme should have prefLabel defined")
+	}
+
+	// Construct the request url. It looks like smartlogicURL?path=task:MyModel:Mytask/skos:ConceptScheme/rdf:instance.
+	reqURL := c.baseAPIURL
+	rawQuery := c.TaskQuery(task).Path("skos:ConceptScheme", "rdf:instance").Encode()
+	if c.warningsAccepted(opts) {
+		rawQuery += "&warningsAccepted=true"
+	}
+	reqURL.RawQuery = rawQuery
+
+	body, err := json.Marshal(inputConceptScheme{
+		ID:   scheme.ID,
+		Type: []string{"skos:ConceptScheme"},
+		PrefLabel: []conceptLabel{{
+			LiteralForm: []wordValue{{Value: scheme.PrefLabel, Language: "en"}},
+			Type:        []string{"skosxl:Label"},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed json encoding concept scheme: %w", err)
+	}
+
+	resp, err := c.makeAuthorizedRequest(ctx, http.MethodPost, reqURL.String(), body, opts...)
+	if err != nil {
+		return fmt.Errorf("failed creating new concept scheme: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed creating new concept scheme: %w", newWriteError(resp))
+	}
+
+	return nil
+}
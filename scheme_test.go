@@ -0,0 +1,92 @@
+package smartlogic
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestClientConceptSchemes(t *testing.T) {
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/token" {
+				handleTokenRequest(t, w)
+				return
+			}
+			w.Header().Set("Content-Type", "application/ld+json")
+			var body string
+			switch req.URL.Query().Get("path") {
+			case "task:testModel:testTask/skos:ConceptScheme/meta:transitiveInstance":
+				body = `{"@graph":[{"@id":"http://www.ft.com/ontology/scheme/Topics","skosxl:prefLabel":[{"skosxl:literalForm":[{"@value":"Topics","@language":"en"}]}]},{"@id":"http://www.ft.com/ontology/scheme/Brands","meta:displayName":"Brands"}]}`
+			case "task:testModel:testTask/" + url.QueryEscape("<http://www.ft.com/ontology/scheme/Topics>") + "/skos:hasTopConcept":
+				body = `{"@graph":[{"@id":"http://www.ft.com/thing/1e5fd0a8-7a8c-4b9c-8e0e-2d1c2b3b7f4c","@type":["skos:Concept","http://www.ft.com/ontology/Topic"],"skosxl:prefLabel":[{"skosxl:literalForm":[{"@value":"Climate","@language":"en"}]}],"skos:topConceptOf":[{"@id":"http://www.ft.com/ontology/scheme/Topics"}]}]}`
+			case "task:testModel:testTask/skos:ConceptScheme/rdf:instance":
+				data, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Errorf("invalid body send on create concept scheme: %v", err)
+				}
+				if string(data) != `{"@id":"http://www.ft.com/ontology/scheme/Regions","@type":["skos:ConceptScheme"],"skosxl:prefLabel":[{"skosxl:literalForm":[{"@value":"Regions","@language":"en"}],"@type":["skosxl:Label"]}]}` {
+					t.Errorf("invalid body send on create concept scheme: got %s", string(data))
+				}
+				w.WriteHeader(http.StatusCreated)
+				return
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if _, err := w.Write([]byte(body)); err != nil {
+				t.Fatal(err)
+			}
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+
+	client, err := NewClient(ctx, testServer.Client(), serverURL, "testClientID", "testAPIKey", "testModel")
+	if err != nil {
+		t.Fatalf("failed creating Smartlogic client: %v", err)
+	}
+
+	schemes, err := client.ListConceptSchemes(ctx, "testTask")
+	if err != nil {
+		t.Fatalf("failed listing concept schemes: %v", err)
+	}
+	expectedSchemes := []ConceptScheme{
+		{ID: "http://www.ft.com/ontology/scheme/Topics", PrefLabel: "Topics"},
+		{ID: "http://www.ft.com/ontology/scheme/Brands", PrefLabel: "Brands"},
+	}
+	if !reflect.DeepEqual(schemes, expectedSchemes) {
+		t.Errorf("unexpected concept schemes, got %+v, want %+v", schemes, expectedSchemes)
+	}
+
+	topConcepts, err := client.GetTopConcepts(ctx, "testTask", ConceptSchemaTopic)
+	if err != nil {
+		t.Fatalf("failed getting top concepts: %v", err)
+	}
+	if len(topConcepts) != 1 || topConcepts[0].PrefLabel != "Climate" {
+		t.Errorf("unexpected top concepts, got %+v", topConcepts)
+	}
+
+	if err = client.ValidateSchemaObject(ctx, "testTask", Concept{SchemaObject: ConceptSchemaTopic}); err != nil {
+		t.Errorf("unexpected error validating existing scheme: %v", err)
+	}
+	err = client.ValidateSchemaObject(ctx, "testTask", Concept{SchemaObject: ConceptSchemaPerson})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found error validating missing scheme, got %v", err)
+	}
+
+	err = client.CreateConceptScheme(ctx, "testTask", ConceptScheme{ID: "http://www.ft.com/ontology/scheme/Regions", PrefLabel: "Regions"})
+	if err != nil {
+		t.Errorf("failed creating concept scheme: %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package smartlogic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+)
+
+// TokenRefreshMargin is how long before its expiry the access token is refreshed,
+// so that requests are not made with a token which expires on the way.
+const TokenRefreshMargin = 30 * time.Second
+
+// Token is an access token for the Smartlogic API together with its expiry.
+// Zero Expiry means the token is used until Smartlogic rejects it.
+type Token struct {
+	AccessToken string
+	Expiry      time.Time
+}
+
+// TokenSource provides access tokens for the Smartlogic API.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// ReuseTokenSource returns TokenSource which caches the tokens of src until they are about to expire or are rejected
+// by Smartlogic. It is safe for concurrent use and concurrent callers needing a new token share a single refresh,
+// so it can be shared by several Clients, e.g. working with different models.
+// Clients wrap the token sources they are given in ReuseTokenSource unless they already are one.
+func ReuseTokenSource(src TokenSource) TokenSource {
+	if m, ok := src.(*tokenManager); ok {
+		return m
+	}
+	return newTokenManager(src)
+}
+
+// APIKeyTokenSource returns TokenSource getting the tokens from the Smartlogic Cloud token endpoint
+// in exchange for the API key.
+func APIKeyTokenSource(httpClient *http.Client, baseCloudURL *url.URL, apiKey string) TokenSource {
+	tokenURL := *baseCloudURL
+	tokenURL.Path = path.Join(baseCloudURL.Path, "token")
+
+	return &apiKeyTokenSource{
+		httpClient: httpClient,
+		tokenURL:   tokenURL,
+		apiKey:     apiKey,
+	}
+}
+
+// StaticTokenSource returns TokenSource which always returns the same never expiring token. It is useful in tests.
+func StaticTokenSource(accessToken string) TokenSource {
+	return staticTokenSource{token: Token{AccessToken: accessToken}}
+}
+
+type apiKeyTokenSource struct {
+	httpClient *http.Client
+	tokenURL   url.URL
+	apiKey     string
+}
+
+func (s *apiKeyTokenSource) Token(ctx context.Context) (*Token, error) {
+	data := url.Values{}
+	data.Set("grant_type", "apikey")
+	data.Set("key", s.apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL.String(), bytes.NewBufferString(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed creating access token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed making access token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed getting access token: %w", newAPIError(resp))
+	}
+
+	tokenResp := struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}{}
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&tokenResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding access token in response body: %w", err)
+	}
+
+	token := &Token{AccessToken: tokenResp.AccessToken}
+	if tokenResp.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+type staticTokenSource struct {
+	token Token
+}
+
+func (s staticTokenSource) Token(ctx context.Context) (*Token, error) {
+	token := s.token
+	return &token, nil
+}
+
+// tokenManager caches the access token and is safe for concurrent use.
+// Concurrent callers needing a new token share a single refresh.
+type tokenManager struct {
+	src TokenSource
+	now func() time.Time
+
+	mu      sync.Mutex
+	token   Token
+	refresh *tokenRefresh
+}
+
+// tokenRefresh is a refresh in flight, the callers waiting for it are released when done is closed.
+type tokenRefresh struct {
+	done  chan struct{}
+	token Token
+	err   error
+}
+
+func newTokenManager(src TokenSource) *tokenManager {
+	return &tokenManager{
+		src: src,
+		now: time.Now,
+	}
+}
+
+// Token returns the cached access token, or fetches a new one if there is none or it is about to expire.
+// Concurrent callers share a single fetch, which is made with the context of the caller starting it.
+// When that caller is cancelled, the callers still waiting start a new fetch with their own context.
+func (m *tokenManager) Token(ctx context.Context) (*Token, error) {
+	for {
+		m.mu.Lock()
+		if m.valid() {
+			token := m.token
+			m.mu.Unlock()
+			return &token, nil
+		}
+
+		r := m.refresh
+		if r == nil {
+			break
+		}
+		m.mu.Unlock()
+
+		select {
+		case <-r.done:
+			if isContextError(r.err) && ctx.Err() == nil {
+				continue
+			}
+			return r.result()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	r := &tokenRefresh{done: make(chan struct{})}
+	m.refresh = r
+	m.mu.Unlock()
+
+	token, err := m.src.Token(ctx)
+	if err == nil && (token == nil || token.AccessToken == "") {
+		err = errors.New("token source returned empty access token")
+	}
+
+	m.mu.Lock()
+	if err == nil {
+		m.token = *token
+		r.token = *token
+	}
+	r.err = err
+	m.refresh = nil
+	close(r.done)
+	m.mu.Unlock()
+
+	return r.result()
+}
+
+// Invalidate drops the cached token if it is the given one, which was rejected by Smartlogic.
+// Tokens already replaced by a refresh are ignored, so concurrent rejections result in a single refresh.
+func (m *tokenManager) Invalidate(accessToken string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.token.AccessToken == accessToken {
+		m.token = Token{}
+	}
+}
+
+func (m *tokenManager) valid() bool {
+	if m.token.AccessToken == "" {
+		return false
+	}
+	return m.token.Expiry.IsZero() || m.now().Add(TokenRefreshMargin).Before(m.token.Expiry)
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func (r *tokenRefresh) result() (*Token, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	token := r.token
+	return &token, nil
+}
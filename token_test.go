@@ -0,0 +1,185 @@
+package smartlogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tokenSourceFunc is TokenSource calling the function.
+type tokenSourceFunc func(ctx context.Context) (*Token, error)
+
+func (f tokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+func TestTokenManagerSharesConcurrentRefresh(t *testing.T) {
+	var fetches int32
+	release := make(chan struct{})
+	tokens := newTokenManager(tokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		n := atomic.AddInt32(&fetches, 1)
+		<-release
+		return &Token{AccessToken: fmt.Sprintf("token_%d", n)}, nil
+	}))
+
+	ctx := context.TODO()
+	results := make(chan string, 10)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := tokens.Token(ctx)
+			if err != nil {
+				t.Errorf("unexpected error getting token: %v", err)
+				return
+			}
+			results <- token.AccessToken
+		}()
+	}
+	// Give the goroutines time to block on the refresh in flight.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	if fetches != 1 {
+		t.Errorf("unexpected number of token fetches, got %d, want 1", fetches)
+	}
+	for token := range results {
+		if token != "token_1" {
+			t.Errorf("unexpected token returned, got %v, want token_1", token)
+		}
+	}
+}
+
+func TestTokenManagerRefreshCancelledByFirstCaller(t *testing.T) {
+	var fetches int32
+	started := make(chan struct{})
+	tokens := newTokenManager(tokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		n := atomic.AddInt32(&fetches, 1)
+		if n == 1 {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return &Token{AccessToken: fmt.Sprintf("token_%d", n)}, nil
+	}))
+
+	firstCtx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := tokens.Token(firstCtx)
+		firstErr <- err
+	}()
+	<-started
+
+	waiterToken := make(chan string, 1)
+	go func() {
+		token, err := tokens.Token(context.Background())
+		if err != nil {
+			t.Errorf("unexpected error getting token for waiting caller: %v", err)
+			waiterToken <- ""
+			return
+		}
+		waiterToken <- token.AccessToken
+	}()
+	// Give the waiting caller time to block on the refresh in flight.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancelled error for first caller, got %v", err)
+	}
+	if token := <-waiterToken; token != "token_2" {
+		t.Errorf("unexpected token returned to waiting caller, got %v, want token_2", token)
+	}
+}
+
+func TestTokenManagerRefreshesBeforeExpiry(t *testing.T) {
+	now := time.Date(2020, time.March, 4, 10, 0, 0, 0, time.UTC)
+	var fetches int
+	tokens := newTokenManager(tokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		fetches++
+		return &Token{AccessToken: fmt.Sprintf("token_%d", fetches), Expiry: now.Add(time.Hour)}, nil
+	}))
+	tokens.now = func() time.Time { return now }
+
+	ctx := context.TODO()
+	expectToken := func(expected string) {
+		t.Helper()
+		token, err := tokens.Token(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error getting token: %v", err)
+		}
+		if token.AccessToken != expected {
+			t.Errorf("unexpected token returned, got %v, want %v", token.AccessToken, expected)
+		}
+	}
+
+	expectToken("token_1")
+	now = now.Add(time.Hour - TokenRefreshMargin - time.Second)
+	expectToken("token_1")
+	now = now.Add(time.Second)
+	expectToken("token_2")
+
+	// Rejections of a token which was already replaced must not cause another refresh.
+	tokens.Invalidate("token_1")
+	expectToken("token_2")
+	tokens.Invalidate("token_2")
+	expectToken("token_3")
+}
+
+func TestClientsShareTokenSource(t *testing.T) {
+	var fetches int32
+	tokens := ReuseTokenSource(tokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		atomic.AddInt32(&fetches, 1)
+		return &Token{AccessToken: "shared_token"}, nil
+	}))
+
+	testServer := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Header.Get("Authorization") != "Bearer shared_token" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}))
+	defer testServer.Close()
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.TODO()
+	for _, model := range []string{"firstModel", "secondModel"} {
+		client, err := NewClientWithTokenSource(ctx, testServer.Client(), serverURL, "testClientID", model, tokens)
+		if err != nil {
+			t.Fatalf("failed creating Smartlogic client: %v", err)
+		}
+		err = client.AddConceptMetadataField(ctx, "conceptID", "factsetIdentifier", "factsetID", "testTask")
+		if err != nil {
+			t.Errorf("failed adding concept metadata field: %v", err)
+		}
+	}
+
+	if fetches != 1 {
+		t.Errorf("unexpected number of token fetches, got %d, want 1", fetches)
+	}
+}
+
+func TestStaticTokenSource(t *testing.T) {
+	token, err := StaticTokenSource("static_token").Token(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected error getting token: %v", err)
+	}
+	if token.AccessToken != "static_token" || !token.Expiry.IsZero() {
+		t.Errorf("unexpected token returned, got %+v", token)
+	}
+}